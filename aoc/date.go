@@ -1,11 +1,37 @@
 package aoc
 
+import "time"
+
 // Date represents an Advent of Code date
 type Date struct {
 	Year
 	Day
 }
 
+// UnlockTime returns the instant the puzzle of d unlocks.
+func (d Date) UnlockTime() time.Time {
+	return Event(d.Year).Unlock(d.Day)
+}
+
+// Unlocked reports whether the puzzle of d is unlocked at now.
+func (d Date) Unlocked(now time.Time) bool {
+	return !now.Before(d.UnlockTime())
+}
+
+// NextUnlock returns the date of the first puzzle which unlocks after now.
+func NextUnlock(now time.Time) Date {
+	year := Year(now.In(Timezone).Year())
+	if year < FirstYear {
+		return Date{Year: FirstYear, Day: FirstDay}
+	}
+	for _, day := range Event(year).Days() {
+		if d := (Date{Year: year, Day: day}); !d.Unlocked(now) {
+			return d
+		}
+	}
+	return Date{Year: year + 1, Day: FirstDay}
+}
+
 // DatePart represents an Advent of Code date for a solution.
 type DatePart struct {
 	Date
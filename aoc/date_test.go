@@ -0,0 +1,134 @@
+package aoc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateUnlocked(t *testing.T) {
+	testCases := []struct {
+		date Date
+		now  time.Time
+		want bool
+		desc string
+	}{
+		{
+			date: Date{2015, 1},
+			now:  time.Date(2015, time.December, 1, 4, 59, 59, 999999999, time.UTC),
+			want: false,
+			desc: "Just before the first unlock",
+		},
+		{
+			date: Date{2015, 1},
+			now:  time.Date(2015, time.December, 1, 5, 0, 0, 0, time.UTC),
+			want: true,
+			desc: "Exactly at the first unlock",
+		},
+		{
+			date: Date{2020, 1},
+			now:  time.Date(2020, time.November, 30, 23, 59, 59, 0, Timezone),
+			want: false,
+			desc: "Leap year just before unlock",
+		},
+		{
+			date: Date{2020, 1},
+			now:  time.Date(2020, time.December, 1, 0, 0, 0, 0, Timezone),
+			want: true,
+			desc: "Leap year at unlock",
+		},
+		{
+			date: Date{2020, 25},
+			now:  time.Date(2020, time.December, 25, 4, 59, 59, 0, time.UTC),
+			want: false,
+			desc: "Just before the last day",
+		},
+		{
+			date: Date{2020, 25},
+			now:  time.Date(2020, time.December, 25, 5, 0, 0, 0, time.UTC),
+			want: true,
+			desc: "At the last day",
+		},
+		{
+			date: Date{2025, 12},
+			now:  time.Date(2025, time.December, 12, 5, 0, 0, 0, time.UTC),
+			want: true,
+			desc: "At the last day of a 12 day event",
+		},
+		{
+			date: Date{2020, 5},
+			now:  time.Date(2020, time.December, 5, 6, 0, 0, 0, time.FixedZone("UTC+1", 60*60)),
+			want: true,
+			desc: "Other timezone at unlock",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.date.Unlocked(tc.now); got != tc.want {
+				t.Errorf("expected %t, got %t (unlock at %v)", tc.want, got, tc.date.UnlockTime())
+			}
+		})
+	}
+}
+
+func TestNextUnlock(t *testing.T) {
+	testCases := []struct {
+		now  time.Time
+		want Date
+		desc string
+	}{
+		{
+			now:  time.Date(2010, time.June, 1, 0, 0, 0, 0, time.UTC),
+			want: Date{2015, 1},
+			desc: "Before the first AoC",
+		},
+		{
+			now:  time.Date(2015, time.December, 1, 4, 59, 59, 0, time.UTC),
+			want: Date{2015, 1},
+			desc: "Just before the first unlock",
+		},
+		{
+			now:  time.Date(2015, time.December, 1, 5, 0, 0, 0, time.UTC),
+			want: Date{2015, 2},
+			desc: "Exactly at the first unlock",
+		},
+		{
+			now:  time.Date(2020, time.February, 29, 12, 0, 0, 0, time.UTC),
+			want: Date{2020, 1},
+			desc: "Leap day",
+		},
+		{
+			now:  time.Date(2020, time.December, 24, 23, 59, 59, 0, Timezone),
+			want: Date{2020, 25},
+			desc: "Just before the last day",
+		},
+		{
+			now:  time.Date(2020, time.December, 25, 5, 0, 0, 0, time.UTC),
+			want: Date{2021, 1},
+			desc: "At the last day",
+		},
+		{
+			now:  time.Date(2021, time.January, 1, 2, 0, 0, 0, time.UTC),
+			want: Date{2021, 1},
+			desc: "New year in UTC, still December in puzzle timezone",
+		},
+		{
+			now:  time.Date(2025, time.December, 12, 4, 59, 59, 0, time.UTC),
+			want: Date{2025, 12},
+			desc: "Just before the last day of a 12 day event",
+		},
+		{
+			now:  time.Date(2025, time.December, 12, 5, 0, 0, 0, time.UTC),
+			want: Date{2026, 1},
+			desc: "At the last day of a 12 day event",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := NextUnlock(tc.now); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
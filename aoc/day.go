@@ -11,7 +11,8 @@ func (d Day) String() string {
 	return strconv.Itoa(int(d))
 }
 
-// First and last Day constants.
+// First and last Day constants. LastDay is the final day of the longest
+// events, use Event to get the days of a specific year.
 const (
 	FirstDay Day = 1
 	LastDay      = FirstDay + 24
@@ -0,0 +1,67 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNoSuchDay is returned when a day is not part of an event.
+var ErrNoSuchDay = errors.New("no such day")
+
+// Calendar describes the puzzles of a single Advent of Code event.
+type Calendar struct {
+	Year Year
+	last Day
+}
+
+// Event returns the calendar of the Advent of Code held in y. Since 2025 an
+// event lasts 12 days instead of 25.
+func Event(y Year) Calendar {
+	last := LastDay
+	if y >= 2025 {
+		last = 12
+	}
+	return Calendar{Year: y, last: last}
+}
+
+// Days returns all days of the event in ascending order.
+func (c Calendar) Days() []Day {
+	days := make([]Day, 0, c.last)
+	for d := FirstDay; d <= c.last; d++ {
+		days = append(days, d)
+	}
+	return days
+}
+
+// LastDay returns the final day of the event.
+func (c Calendar) LastDay() Day {
+	return c.last
+}
+
+// Has reports whether d is a day of the event.
+func (c Calendar) Has(d Day) bool {
+	return d >= FirstDay && d <= c.last
+}
+
+// SinglePart reports whether d only has a single puzzle. This is the case for
+// the final day of every event of which the second star is awarded for
+// completing all other puzzles.
+func (c Calendar) SinglePart(d Day) bool {
+	return d == c.last
+}
+
+// Unlock returns the instant the puzzle of d unlocks.
+func (c Calendar) Unlock(d Day) time.Time {
+	return time.Date(int(c.Year), time.December, int(d), 0, 0, 0, 0, Timezone)
+}
+
+// Validate returns an error wrapping ErrNoSuchDay if d is not a day of the
+// event held in its year.
+func (d Date) Validate() error {
+	if c := Event(d.Year); !c.Has(d.Day) {
+		return fmt.Errorf("advent of code %s has days %s to %s, got day %s: %w",
+			d.Year, FirstDay, c.LastDay(), d.Day, ErrNoSuchDay)
+	}
+	return nil
+}
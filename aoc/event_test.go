@@ -0,0 +1,49 @@
+package aoc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEvent(t *testing.T) {
+	testCases := []struct {
+		year Year
+		last Day
+	}{
+		{year: 2015, last: 25},
+		{year: 2024, last: 25},
+		{year: 2025, last: 12},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.year.String(), func(t *testing.T) {
+			c := Event(tc.year)
+			if got := c.LastDay(); got != tc.last {
+				t.Errorf("expected last day %d, got %d", tc.last, got)
+			}
+			if got := len(c.Days()); got != int(tc.last) {
+				t.Errorf("expected %d days, got %d", tc.last, got)
+			}
+			if !c.SinglePart(tc.last) || c.SinglePart(tc.last-1) {
+				t.Errorf("expected only day %d to be single part", tc.last)
+			}
+			if c.Has(0) || !c.Has(1) || !c.Has(tc.last) || c.Has(tc.last+1) {
+				t.Errorf("expected days 1 to %d only", tc.last)
+			}
+
+			err := Date{Year: tc.year, Day: tc.last + 1}.Validate()
+			if !errors.Is(err, ErrNoSuchDay) {
+				t.Errorf("expected ErrNoSuchDay, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCalendarUnlock(t *testing.T) {
+	got := Event(2020).Unlock(5)
+	want := time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -5,12 +5,20 @@ import (
 	"net/http"
 )
 
+// BaseURL is the URL of the Advent of Code website which all requests are
+// made against.
+var BaseURL = "https://adventofcode.com"
+
 // CreateInputReq creates an HTTP request for retrieving the Advent of Code
 // input given d.
 func CreateInputReq(d Date, sessionID string) (*http.Request, error) {
-	url := fmt.Sprintf("https://adventofcode.com/%d/day/%d/input", d.Year, d.Day)
+	return createReq(fmt.Sprintf("/%d/day/%d/input", d.Year, d.Day), sessionID)
+}
 
-	req, err := http.NewRequest("GET", url, nil)
+// createReq creates a GET request for path which is authenticated with
+// sessionID.
+func createReq(path, sessionID string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", BaseURL+path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,113 @@
+package aoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// CreateLeaderboardReq creates an HTTP request for retrieving the JSON of the
+// private leaderboard id in year y.
+func CreateLeaderboardReq(y Year, id, sessionID string) (*http.Request, error) {
+	return createReq(fmt.Sprintf("/%d/leaderboard/private/view/%s.json", y, id), sessionID)
+}
+
+// ParseLeaderboard parses the JSON of a private leaderboard.
+func ParseLeaderboard(b []byte) (*Leaderboard, error) {
+	var l Leaderboard
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, fmt.Errorf("invalid leaderboard: %w", err)
+	}
+	return &l, nil
+}
+
+// Leaderboard represents a private leaderboard.
+type Leaderboard struct {
+	Event   string            `json:"event"`
+	OwnerID Int               `json:"owner_id"`
+	Members map[string]Member `json:"members"`
+}
+
+// Ranked returns the members ordered by their local score, highest first.
+// Ties are broken by stars and then by who got their last star first.
+func (l *Leaderboard) Ranked() []Member {
+	members := make([]Member, 0, len(l.Members))
+	for _, m := range l.Members {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if a.LocalScore != b.LocalScore {
+			return a.LocalScore > b.LocalScore
+		}
+		if a.Stars != b.Stars {
+			return a.Stars > b.Stars
+		}
+		if a.LastStarTs != b.LastStarTs {
+			return a.LastStarTs < b.LastStarTs
+		}
+		return a.ID < b.ID
+	})
+	return members
+}
+
+// Member represents a member of a private leaderboard.
+type Member struct {
+	ID          Int    `json:"id"`
+	Name        string `json:"name"`
+	Stars       int    `json:"stars"`
+	LocalScore  int    `json:"local_score"`
+	GlobalScore int    `json:"global_score"`
+	LastStarTs  Int    `json:"last_star_ts"`
+
+	// CompletionDayLevel maps a day and a part level ("1" or "2") to the star
+	// earned for it.
+	CompletionDayLevel map[string]map[string]Star `json:"completion_day_level"`
+}
+
+// DisplayName returns the name of m as shown on the website.
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+	return m.Name
+}
+
+// Star returns the time m earned the star of d and p if any.
+func (m Member) Star(d Day, p Part) (time.Time, bool) {
+	level := strconv.Itoa(int(p-Part1) + 1)
+	s, ok := m.CompletionDayLevel[d.String()][level]
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(s.GetStarTs), 0), true
+}
+
+// Star represents an earned star of a part.
+type Star struct {
+	GetStarTs Int `json:"get_star_ts"`
+	StarIndex Int `json:"star_index"`
+}
+
+// Int is an integer which is encoded in the leaderboard JSON as either a
+// number or a string depending on the year it was fetched.
+type Int int64
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.
+func (i *Int) UnmarshalJSON(b []byte) error {
+	if len(b) > 1 && b[0] == '"' {
+		b = b[1 : len(b)-1]
+	}
+	if string(b) == "null" {
+		return nil
+	}
+	n, err := strconv.ParseInt(string(b), 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = Int(n)
+	return nil
+}
@@ -0,0 +1,50 @@
+package aoc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLeaderboard(t *testing.T) {
+	testCases := []struct {
+		json string
+		desc string
+	}{
+		{
+			json: `{"event":"2020","owner_id":"1","members":{"1":{"id":"1","name":"alice","stars":1,"local_score":2,"global_score":0,"last_star_ts":"1606802400","completion_day_level":{"1":{"1":{"get_star_ts":"1606802400"}}}}}}`,
+			desc: "Quoted numbers",
+		},
+		{
+			json: `{"event":"2023","owner_id":1,"members":{"1":{"id":1,"name":"alice","stars":1,"local_score":2,"global_score":0,"last_star_ts":1606802400,"completion_day_level":{"1":{"1":{"get_star_ts":1606802400,"star_index":7}}}}}}`,
+			desc: "Plain numbers",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			l, err := ParseLeaderboard([]byte(tc.json))
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := l.Members["1"]
+			if m.ID != 1 || m.DisplayName() != "alice" {
+				t.Errorf("unexpected member %+v", m)
+			}
+
+			got, ok := m.Star(1, Part1)
+			if want := time.Unix(1606802400, 0); !ok || !got.Equal(want) {
+				t.Errorf("expected star at %v, got %v (%t)", want, got, ok)
+			}
+			if _, ok := m.Star(1, Part2); ok {
+				t.Error("expected no star for part B")
+			}
+		})
+	}
+}
+
+func TestMemberDisplayName(t *testing.T) {
+	m := Member{ID: 42}
+	if got, want := m.DisplayName(), "(anonymous user #42)"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,123 @@
+package aoc
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dateRe matches the date notations accepted by ParseDate and ParseDatePart
+// followed by an optional part.
+var dateRe = regexp.MustCompile(`^(\d{4}|\d{2})(?:[/.]|-12-)(\d{1,2})(?:[/.-]?([aAbB])|[/.-]([12]))?$`)
+
+// ParseDate parses a date in one of the following notations:
+//
+//	2020/5
+//	2020.5
+//	2020-12-05
+//	20/5
+//	20.5
+//
+// A two digit year is relative to the year 2000. The day must be a day of the
+// event held in the year.
+func ParseDate(s string) (Date, error) {
+	d, part, err := parse(s)
+	if err != nil {
+		return Date{}, err
+	}
+	if part != 0 {
+		return Date{}, fmt.Errorf("invalid date %q: unexpected part", s)
+	}
+	return d, nil
+}
+
+// ParseDatePart parses a date in one of the notations accepted by ParseDate
+// followed by a part. The part is either A or B, in any case, or 1 or 2 when
+// preceded by a separator:
+//
+//	2020/5A
+//	2020/5/b
+//	2020-12-05.2
+//	20.5b
+func ParseDatePart(s string) (DatePart, error) {
+	d, part, err := parse(s)
+	if err != nil {
+		return DatePart{}, err
+	}
+	if part == 0 {
+		return DatePart{}, fmt.Errorf("invalid date %q: missing part", s)
+	}
+	return DatePart{Date: d, Part: part}, nil
+}
+
+func parse(s string) (Date, Part, error) {
+	m := dateRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Date{}, 0, fmt.Errorf("invalid date %q", s)
+	}
+
+	year, _ := strconv.Atoi(m[1])
+	if len(m[1]) == 2 {
+		year += 2000
+	}
+	day, _ := strconv.Atoi(m[2])
+	d := Date{Year: Year(year), Day: Day(day)}
+	if d.Year < FirstYear {
+		return Date{}, 0, fmt.Errorf("invalid date %q: the first advent of code was held in %s", s, FirstYear)
+	}
+	if err := d.Validate(); err != nil {
+		return Date{}, 0, fmt.Errorf("invalid date %q: %w", s, err)
+	}
+
+	var part Part
+	switch strings.ToUpper(m[3] + m[4]) {
+	case "A", "1":
+		part = Part1
+	case "B", "2":
+		part = Part2
+	}
+	return d, part, nil
+}
+
+// String formats d as year/day, e.g. 2020/5.
+func (d Date) String() string {
+	return d.Year.String() + "/" + d.Day.String()
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface. It accepts
+// all notations of ParseDate.
+func (d *Date) UnmarshalText(b []byte) error {
+	parsed, err := ParseDate(string(b))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// String formats dp as year/day followed by the part, e.g. 2020/5A.
+func (dp DatePart) String() string {
+	return dp.Date.String() + dp.Part.String()
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface.
+func (dp DatePart) MarshalText() ([]byte, error) {
+	return []byte(dp.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface. It accepts
+// all notations of ParseDatePart.
+func (dp *DatePart) UnmarshalText(b []byte) error {
+	parsed, err := ParseDatePart(string(b))
+	if err != nil {
+		return err
+	}
+	*dp = parsed
+	return nil
+}
@@ -0,0 +1,109 @@
+package aoc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDate(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want Date
+		ok   bool
+	}{
+		{s: "2020/5", want: Date{2020, 5}, ok: true},
+		{s: "2020/05", want: Date{2020, 5}, ok: true},
+		{s: "2020.25", want: Date{2020, 25}, ok: true},
+		{s: "2020-12-05", want: Date{2020, 5}, ok: true},
+		{s: "20/5", want: Date{2020, 5}, ok: true},
+		{s: "20.5", want: Date{2020, 5}, ok: true},
+		{s: " 2015/1\n", want: Date{2015, 1}, ok: true},
+		{s: "", ok: false},
+		{s: "2020", ok: false},
+		{s: "2020/", ok: false},
+		{s: "2020/0", ok: false},
+		{s: "2020/26", ok: false},
+		{s: "2025/13", ok: false},
+		{s: "2014/1", ok: false},
+		{s: "2020-11-05", ok: false},
+		{s: "2020/5b", ok: false},
+		{s: "5/2020", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := ParseDate(tc.s)
+			if tc.ok && err != nil {
+				t.Fatal(err)
+			}
+			if !tc.ok && err == nil {
+				t.Fatalf("expected an error, got %v", got)
+			}
+			if got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDatePart(t *testing.T) {
+	testCases := []struct {
+		s    string
+		want DatePart
+		ok   bool
+	}{
+		{s: "2020/5A", want: DatePart{Date{2020, 5}, Part1}, ok: true},
+		{s: "2020/5b", want: DatePart{Date{2020, 5}, Part2}, ok: true},
+		{s: "2020/5/b", want: DatePart{Date{2020, 5}, Part2}, ok: true},
+		{s: "2020-12-05.2", want: DatePart{Date{2020, 5}, Part2}, ok: true},
+		{s: "2020/5/1", want: DatePart{Date{2020, 5}, Part1}, ok: true},
+		{s: "20.5b", want: DatePart{Date{2020, 5}, Part2}, ok: true},
+		{s: "2020/5", ok: false},
+		{s: "2020/5c", ok: false},
+		{s: "2020/53", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := ParseDatePart(tc.s)
+			if tc.ok && err != nil {
+				t.Fatal(err)
+			}
+			if !tc.ok && err == nil {
+				t.Fatalf("expected an error, got %v", got)
+			}
+			if got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDateText(t *testing.T) {
+	dp := DatePart{Date{2020, 5}, Part2}
+	if got := dp.String(); got != "2020/5B" {
+		t.Errorf("expected 2020/5B, got %s", got)
+	}
+
+	b, err := json.Marshal(struct {
+		Date     Date
+		DatePart DatePart
+	}{dp.Date, dp})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Date":"2020/5","DatePart":"2020/5B"}`; string(b) != want {
+		t.Errorf("expected %s, got %s", want, b)
+	}
+
+	var got struct {
+		Date     Date
+		DatePart DatePart
+	}
+	if err := json.Unmarshal([]byte(`{"Date":"2020-12-05","DatePart":"20.5b"}`), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Date != dp.Date || got.DatePart != dp {
+		t.Errorf("expected %v and %v, got %v and %v", dp.Date, dp, got.Date, got.DatePart)
+	}
+}
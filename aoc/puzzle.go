@@ -0,0 +1,26 @@
+package aoc
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+)
+
+// CreatePuzzleReq creates an HTTP request for retrieving the puzzle page of d
+// which shows the answers given for its solved parts.
+func CreatePuzzleReq(d Date, sessionID string) (*http.Request, error) {
+	return createReq(fmt.Sprintf("/%d/day/%d", d.Year, d.Day), sessionID)
+}
+
+var puzzleAnswerRe = regexp.MustCompile(`Your puzzle answer was <code>([^<]*)</code>`)
+
+// ParsePuzzleAnswers returns the accepted answers shown on a puzzle page in
+// the order of their parts.
+func ParsePuzzleAnswers(page []byte) []string {
+	var answers []string
+	for _, m := range puzzleAnswerRe.FindAllSubmatch(page, -1) {
+		answers = append(answers, html.UnescapeString(string(m[1])))
+	}
+	return answers
+}
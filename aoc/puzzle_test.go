@@ -0,0 +1,22 @@
+package aoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePuzzleAnswers(t *testing.T) {
+	page := []byte(`<article class="day-desc"><h2>--- Day 5: Binary Boarding ---</h2></article>
+<p>Your puzzle answer was <code>842</code>.</p><article class="day-desc"><h2 id="part2">--- Part Two ---</h2></article>
+<p>Your puzzle answer was <code>a&amp;b</code>.</p><p class="day-success">Both parts of this puzzle are complete!</p>`)
+
+	got := ParsePuzzleAnswers(page)
+	want := []string{"842", "a&b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := ParsePuzzleAnswers([]byte(`<p>To play, please identify yourself</p>`)); len(got) != 0 {
+		t.Errorf("expected no answers, got %v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// CreateCalendarReq creates an HTTP request for retrieving the calendar page
+// of y which shows the stars earned per day.
+func CreateCalendarReq(y Year, sessionID string) (*http.Request, error) {
+	return createReq(fmt.Sprintf("/%d", y), sessionID)
+}
+
+var calendarDayRe = regexp.MustCompile(`class="calendar-day(\d+)(?: calendar-(complete|verycomplete))?"`)
+
+// ParseCalendarStars returns the number of stars earned per day shown on a
+// calendar page. Days without any stars are omitted. An error is returned when
+// page does not show a calendar, e.g. when it is the login page.
+func ParseCalendarStars(page []byte) (map[Day]int, error) {
+	days := calendarDayRe.FindAllSubmatch(page, -1)
+	if len(days) == 0 {
+		return nil, errors.New("invalid calendar: no days found")
+	}
+	stars := make(map[Day]int)
+	for _, m := range days {
+		d, err := strconv.Atoi(string(m[1]))
+		if err != nil {
+			continue
+		}
+		switch string(m[2]) {
+		case "complete":
+			stars[Day(d)] = 1
+		case "verycomplete":
+			stars[Day(d)] = 2
+		}
+	}
+	return stars, nil
+}
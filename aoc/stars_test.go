@@ -0,0 +1,25 @@
+package aoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCalendarStars(t *testing.T) {
+	page := []byte(`<pre class="calendar">
+<a aria-label="Day 1, two stars" href="/2020/day/1" class="calendar-day1 calendar-verycomplete">...</a>
+<a aria-label="Day 2, one star" href="/2020/day/2" class="calendar-day2 calendar-complete">...</a>
+<a aria-label="Day 3" href="/2020/day/3" class="calendar-day3">...</a>
+<span aria-hidden="true" class="calendar-day4">...</span>
+</pre>`)
+
+	got, err := ParseCalendarStars(page)
+	want := map[Day]int{1: 2, 2: 1}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v, %v", want, got, err)
+	}
+
+	if _, err := ParseCalendarStars([]byte(`<form action="/auth/login">`)); err == nil {
+		t.Error("expected an error for a page without a calendar")
+	}
+}
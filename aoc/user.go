@@ -0,0 +1,31 @@
+package aoc
+
+import (
+	"errors"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrNotLoggedIn is returned by ParseUser when a page was not requested with a
+// valid session.
+var ErrNotLoggedIn = errors.New("not logged in to Advent of Code")
+
+var userRe = regexp.MustCompile(`<div class="user">([^<]*)`)
+
+// CreateUserReq creates an HTTP request for a cheap page which shows the user
+// the session belongs to.
+func CreateUserReq(sessionID string) (*http.Request, error) {
+	return createReq("/settings", sessionID)
+}
+
+// ParseUser returns the name of the logged in user shown on an Advent of Code
+// page.
+func ParseUser(page []byte) (string, error) {
+	m := userRe.FindSubmatch(page)
+	if m == nil {
+		return "", ErrNotLoggedIn
+	}
+	return strings.TrimSpace(html.UnescapeString(string(m[1]))), nil
+}
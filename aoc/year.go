@@ -49,44 +49,41 @@ func (years AdventYears) Less(i, j int) bool { return years[i] < years[j] }
 // Timezone is when Eric Wastl unlocks the Advent of Code puzzles.
 var Timezone = time.FixedZone("EST/UTC-5", -5*60*60)
 
-func nowLocal() time.Time {
-	return time.Now()
-}
+// Clock returns the current time which is used to determine which puzzles are
+// unlocked. It can be replaced to pretend it is another time.
+var Clock = time.Now
 
-// Years returns all released AoCs years in ascending order. It should be
-// noted that this uses the local time of the user's machine. Which when messed
-// with could lead to an incorrect list of AoC years.
+// Years returns all released AoCs years in ascending order according to
+// Clock.
 func Years() AdventYears {
-	return years(nowLocal)
+	return YearsAt(Clock())
 }
 
-func years(nowf func() time.Time) AdventYears {
-	now := nowf()
-	currYear := Year(now.Year())
-
-	if currYear < FirstYear {
-		return AdventYears{}
-	}
-
-	years := make(AdventYears, 0, currYear-FirstYear+1)
-	for y := FirstYear; y < currYear; y++ {
+// YearsAt returns all AoCs years released at now in ascending order. A year
+// is released once the puzzle of its first day is unlocked.
+func YearsAt(now time.Time) AdventYears {
+	years := AdventYears{}
+	for y := FirstYear; (Date{Year: y, Day: FirstDay}).Unlocked(now); y++ {
 		years = append(years, y)
 	}
-
-	nextAoc := time.Date(int(currYear), time.December, 1, 0, 0, 0, 0, Timezone)
-	if now.After(nextAoc) {
-		years = append(years, currYear)
-	}
-
 	return years
 }
 
-// LastYear yields the most recent Advent of Code year.
+// LastYear yields the most recent Advent of Code year according to Clock. It
+// yields FirstYear when Clock is before the first Advent of Code.
 func LastYear() Year {
-	return lastYear(nowLocal)
+	if y, ok := LastYearAt(Clock()); ok {
+		return y
+	}
+	return FirstYear
 }
 
-func lastYear(nowf func() time.Time) Year {
-	years := years(nowf)
-	return years[len(years)-1]
+// LastYearAt yields the most recent Advent of Code year released at now. It
+// reports false when no year is released yet.
+func LastYearAt(now time.Time) (Year, bool) {
+	years := YearsAt(now)
+	if len(years) == 0 {
+		return 0, false
+	}
+	return years[len(years)-1], true
 }
@@ -32,12 +32,36 @@ func TestYears(t *testing.T) {
 			want: AdventYears{2015, 2016},
 			desc: "2nd AoC",
 		},
+		{
+			t:    time.Date(2016, time.December, 1, 5, 0, 0, 0, time.UTC),
+			want: AdventYears{2015, 2016},
+			desc: "Exactly at unlock",
+		},
+		{
+			t:    time.Date(2016, time.December, 1, 0, 0, 0, 0, Timezone),
+			want: AdventYears{2015, 2016},
+			desc: "Unlock in puzzle timezone",
+		},
+		{
+			t:    time.Date(2020, time.February, 29, 12, 0, 0, 0, time.UTC),
+			want: AdventYears{2015, 2016, 2017, 2018, 2019},
+			desc: "Leap day",
+		},
+		{
+			t:    time.Date(2021, time.January, 1, 2, 0, 0, 0, time.UTC),
+			want: AdventYears{2015, 2016, 2017, 2018, 2019, 2020},
+			desc: "New year in UTC, still December in puzzle timezone",
+		},
+		{
+			t:    time.Date(2014, time.December, 25, 12, 0, 0, 0, time.UTC),
+			want: AdventYears{},
+			desc: "Before the first AoC",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
-			f := func() time.Time { return tc.t }
-			got := years(f)
+			got := YearsAt(tc.t)
 			ok := reflect.DeepEqual(got, tc.want)
 
 			if !ok {
@@ -46,3 +70,21 @@ func TestYears(t *testing.T) {
 		})
 	}
 }
+
+func TestLastYearClock(t *testing.T) {
+	defer func(c func() time.Time) { Clock = c }(Clock)
+	Clock = func() time.Time { return time.Date(2019, time.December, 1, 5, 0, 0, 0, time.UTC) }
+
+	if got := LastYear(); got != 2019 {
+		t.Errorf("expected 2019, got %d", got)
+	}
+}
+
+func TestLastYearAtBeforeFirst(t *testing.T) {
+	if y, ok := LastYearAt(time.Date(2010, time.January, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("expected no year before the first AoC, got %d", y)
+	}
+	if y, ok := LastYearAt(time.Date(2015, time.December, 1, 5, 0, 0, 0, time.UTC)); !ok || y != 2015 {
+		t.Errorf("expected 2015, got %d (%v)", y, ok)
+	}
+}
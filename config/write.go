@@ -0,0 +1,73 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// WriteContents writes b to the given configuration file which is only
+// readable and writable by the user. An existing file is replaced instead of
+// written to, so b is never stored with its looser permissions.
+func WriteContents(file string, b []byte) error {
+	baseConfigDir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(baseConfigDir, 0700); err != nil {
+		return err
+	}
+
+	// TempFile creates the file with permissions 0600.
+	tmp, err := ioutil.TempFile(baseConfigDir, "."+file+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(baseConfigDir, file))
+}
+
+// Restrict tightens the permissions of the given configuration file so that
+// it is only readable and writable by the user. It is a no-op when the file
+// does not exist.
+func Restrict(file string) error {
+	baseConfigDir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(baseConfigDir, file)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if info.Mode().Perm()&0077 == 0 {
+		return nil
+	}
+	return os.Chmod(path, 0600)
+}
+
+// RemoveContents removes the given configuration file. It is a no-op when the
+// file does not exist.
+func RemoveContents(file string) error {
+	baseConfigDir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(baseConfigDir, file))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
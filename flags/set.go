@@ -0,0 +1,173 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IntSet is used to select a set of ints within an *inclusive* range for the
+// std "flag" package. A value is a comma separated list of expressions:
+//
+//	5      a single int
+//	1-5    an inclusive range
+//	20-    from 20 up to and including Max
+//	-5     from Min up to and including 5
+//	all    every int from Min to Max
+//	latest the latest int which is up to the user of the flag to resolve
+//	!3     excludes an int or range, e.g. !1-5
+//
+// Expressions are applied from left to right, so an exclusion only removes
+// what was selected before it, e.g. "1-5,!3,3" selects 1 to 5. An exclusion
+// which comes first, before anything is selected, excludes from all ints.
+type IntSet struct {
+	Min, Max int
+
+	// Latest is set when "latest" was selected.
+	Latest bool
+
+	set map[int]bool
+}
+
+func (s *IntSet) String() string {
+	if s.Latest {
+		return "latest"
+	}
+
+	var parts []string
+	values := s.Values()
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if i == j {
+			parts = append(parts, strconv.Itoa(values[i]))
+		} else {
+			parts = append(parts, strconv.Itoa(values[i])+"-"+strconv.Itoa(values[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set satasfies part of the flag.Value interface.
+// It returns an error if an expression is invalid or an int resides outside of
+// the inclusive int range.
+// Otherwise the selected ints are added to the set.
+func (s *IntSet) Set(v string) error {
+	if strings.TrimSpace(v) == "latest" {
+		if len(s.set) > 0 {
+			return errors.New("latest can not be combined with other values")
+		}
+		s.Latest = true
+		return nil
+	}
+	if s.Latest {
+		return errors.New("latest can not be combined with other values")
+	}
+
+	// The expressions are applied to a copy so an invalid value leaves the
+	// set untouched.
+	set := make(map[int]bool, len(s.set))
+	for n := range s.set {
+		set[n] = true
+	}
+	for i, expr := range strings.Split(v, ",") {
+		expr = strings.TrimSpace(expr)
+		exclude := strings.HasPrefix(expr, "!")
+		if exclude {
+			expr = expr[1:]
+		}
+
+		lo, hi, err := s.parseRange(expr)
+		if err != nil {
+			return err
+		}
+		if exclude && i == 0 && len(set) == 0 {
+			for n := s.Min; n <= s.Max; n++ {
+				set[n] = true
+			}
+		}
+		for n := lo; n <= hi; n++ {
+			if exclude {
+				delete(set, n)
+			} else {
+				set[n] = true
+			}
+		}
+	}
+
+	if len(set) == 0 {
+		return fmt.Errorf("%q selects nothing", v)
+	}
+	s.set = set
+	return nil
+}
+
+// parseRange parses a single int, range or all into its inclusive bounds.
+func (s *IntSet) parseRange(expr string) (lo, hi int, err error) {
+	switch expr {
+	case "":
+		return 0, 0, errors.New("empty value")
+	case "all":
+		return s.Min, s.Max, nil
+	}
+
+	lo, hi = s.Min, s.Max
+	loStr, hiStr := expr, expr
+	if i := strings.Index(expr, "-"); i >= 0 {
+		loStr, hiStr = expr[:i], expr[i+1:]
+		if loStr == "" && hiStr == "" {
+			return 0, 0, fmt.Errorf("invalid range: %q", expr)
+		}
+	}
+
+	if loStr != "" {
+		if lo, err = s.parseInt(loStr); err != nil {
+			return 0, 0, err
+		}
+	}
+	if hiStr != "" {
+		if hi, err = s.parseInt(hiStr); err != nil {
+			return 0, 0, err
+		}
+	}
+	if lo > hi {
+		return 0, 0, fmt.Errorf("invalid range: %q", expr)
+	}
+	return lo, hi, nil
+}
+
+func (s *IntSet) parseInt(v string) (int, error) {
+	num, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, err
+	}
+	if num < s.Min || num > s.Max {
+		return 0, fmt.Errorf("value not in range of (%d..%d): %d", s.Min, s.Max, num)
+	}
+	return num, nil
+}
+
+// Values returns the selected ints in ascending order.
+func (s *IntSet) Values() []int {
+	values := make([]int, 0, len(s.set))
+	for n := range s.set {
+		values = append(values, n)
+	}
+	sort.Ints(values)
+	return values
+}
+
+// Contains reports whether n is selected.
+func (s *IntSet) Contains(n int) bool {
+	return s.set[n]
+}
+
+// Empty reports whether nothing was selected, not even latest.
+func (s *IntSet) Empty() bool {
+	return !s.Latest && len(s.set) == 0
+}
@@ -0,0 +1,88 @@
+package flags_test
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/aod/elver/flags"
+)
+
+func TestIntSetFlagSet(t *testing.T) {
+	testCases := []struct {
+		args   []string
+		desc   string
+		ok     bool
+		want   []int
+		latest bool
+	}{
+		{args: []string{}, desc: "No args", ok: true, want: []int{}},
+		{args: []string{"-num", ""}, desc: "Empty string", ok: false},
+		{args: []string{"-num", "abc"}, desc: "Not a number", ok: false},
+		{args: []string{"-num", "0"}, desc: "Below Min range", ok: false},
+		{args: []string{"-num", "11"}, desc: "Above Max range", ok: false},
+		{args: []string{"-num", "5-11"}, desc: "Range above Max", ok: false},
+		{args: []string{"-num", "5-3"}, desc: "Reversed range", ok: false},
+		{args: []string{"-num", "-"}, desc: "Open range", ok: false},
+		{args: []string{"-num", "!all"}, desc: "Excluding everything", ok: false},
+		{args: []string{"-num", "5"}, desc: "Single value", ok: true, want: []int{5}},
+		{args: []string{"-num", "1-3,7"}, desc: "Range and value", ok: true, want: []int{1, 2, 3, 7}},
+		{args: []string{"-num", "8-"}, desc: "Up to Max", ok: true, want: []int{8, 9, 10}},
+		{args: []string{"-num", "-2"}, desc: "From Min", ok: true, want: []int{1, 2}},
+		{args: []string{"-num", "all"}, desc: "All", ok: true, want: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{args: []string{"-num", "!3"}, desc: "Only an exclusion", ok: true, want: []int{1, 2, 4, 5, 6, 7, 8, 9, 10}},
+		{args: []string{"-num", "1-5,!2-3"}, desc: "Range with exclusion", ok: true, want: []int{1, 4, 5}},
+		{args: []string{"-num", "1-5", "-num", "!3"}, desc: "Exclusion in later flag", ok: true, want: []int{1, 2, 4, 5}},
+		{args: []string{"-num", "!3,3"}, desc: "Exclusion then inclusion", ok: true, want: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		{args: []string{"-num", "1-5,!3,3"}, desc: "Inclusion after exclusion", ok: true, want: []int{1, 2, 3, 4, 5}},
+		{args: []string{"-num", "3,!1-5,2"}, desc: "Exclusion between inclusions", ok: true, want: []int{2}},
+		{args: []string{"-num", "!2,!4"}, desc: "Only exclusions", ok: true, want: []int{1, 3, 5, 6, 7, 8, 9, 10}},
+		{args: []string{"-num", "1-3", "-num", "!2,2"}, desc: "Exclusion first in later flag", ok: true, want: []int{1, 2, 3}},
+		{args: []string{"-num", "1,!1"}, desc: "Excluding the only inclusion", ok: false},
+		{args: []string{"-num", "latest"}, desc: "Latest", ok: true, want: []int{}, latest: true},
+		{args: []string{"-num", "latest", "-num", "1"}, desc: "Latest combined", ok: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			fs := flag.NewFlagSet("intset", flag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			set := &flags.IntSet{Min: 1, Max: 10}
+			fs.Var(set, "num", "select numbers between 1 to 10")
+
+			err := fs.Parse(tc.args)
+			if tc.ok && err != nil {
+				t.Fatal(err)
+			}
+			if !tc.ok {
+				if err == nil {
+					t.Errorf("expected an error, got %v", set.Values())
+				}
+				return
+			}
+			if got := set.Values(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+			if set.Latest != tc.latest {
+				t.Errorf("expected latest %t, got %t", tc.latest, set.Latest)
+			}
+		})
+	}
+}
+
+func ExampleIntSet() {
+	fs := flag.NewFlagSet("example usage of IntSet", flag.ContinueOnError)
+
+	set := flags.IntSet{Min: 1, Max: 25}
+	fs.Var(&set, "days", "select days between 1 to 25")
+
+	fs.Parse([]string{"-days", "1-5,7,20-,!3"})
+
+	fmt.Println(set.Values())
+	fmt.Println(set.String())
+	// Output:
+	// [1 2 4 5 7 20 21 22 23 24 25]
+	// 1-2,4-5,7,20-25
+}
@@ -0,0 +1,73 @@
+// Package capture captures everything written to the standard output and
+// error of the process. Unlike reassigning os.Stdout and os.Stderr this also
+// catches writes made directly to file descriptors 1 and 2, e.g. by cgo code
+// or child processes, on platforms which support duplicating them.
+package capture
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+)
+
+// mu serializes captures because the standard output and error are shared by
+// the whole process.
+var mu sync.Mutex
+
+// redirectStd is redirect, replaced by tests to make redirecting fail.
+var redirectStd = redirect
+
+// Run runs f and returns everything written to the standard output and error
+// while it ran. Only one function runs at a time. f always runs, when the
+// output can not be captured it is let through and the error is returned.
+func Run(f func()) ([]byte, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		f()
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(&buf, r)
+		r.Close()
+		done <- err
+	}()
+
+	err = redirected(w, f)
+	w.Close()
+	if copyErr := <-done; err == nil {
+		err = copyErr
+	}
+	return buf.Bytes(), err
+}
+
+// Discard runs f while discarding everything written to the standard output
+// and error. Only one function runs at a time. Like with Run f always runs.
+func Discard(f func()) error {
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		f()
+		return err
+	}
+	defer null.Close()
+	return redirected(null, f)
+}
+
+// redirected runs f with the standard output and error redirected to w, or
+// without redirecting them when that fails.
+func redirected(w *os.File, f func()) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	restore, err := redirectStd(w)
+	if err != nil {
+		f()
+		return err
+	}
+	defer restore()
+	f()
+	return nil
+}
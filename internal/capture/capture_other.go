@@ -0,0 +1,22 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+package capture
+
+import "os"
+
+// Stdout and Stderr are the original standard output and error of the process
+// which are not captured.
+var (
+	Stdout = os.Stdout
+	Stderr = os.Stderr
+)
+
+// redirect reassigns os.Stdout and os.Stderr on unsupported platforms, so
+// writes made directly to the file descriptors are not captured.
+func redirect(w *os.File) (func(), error) {
+	os.Stdout, os.Stderr = w, w
+	return func() {
+		os.Stdout, os.Stderr = Stdout, Stderr
+	}, nil
+}
@@ -0,0 +1,46 @@
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	out, err := Run(func() {
+		fmt.Println("out")
+		fmt.Fprintln(os.Stderr, "err")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(out), "out\nerr\n"; got != want {
+		t.Errorf("Run() = %q, want %q", got, want)
+	}
+
+	if err := Discard(func() { fmt.Println("discarded") }); err != nil {
+		t.Fatal(err)
+	}
+	out, err = Run(func() {})
+	if err != nil || len(out) != 0 {
+		t.Errorf("Run() = %q, %v, want nothing", out, err)
+	}
+}
+
+func TestRunWithoutRedirect(t *testing.T) {
+	failed := errors.New("redirect failed")
+	redirectStd = func(*os.File) (func(), error) { return nil, failed }
+	defer func() { redirectStd = redirect }()
+
+	ran := 0
+	if out, err := Run(func() { ran++ }); err != failed || len(out) != 0 {
+		t.Errorf("Run() = %q, %v, want nothing and %v", out, err, failed)
+	}
+	if err := Discard(func() { ran++ }); err != failed {
+		t.Errorf("Discard() = %v, want %v", err, failed)
+	}
+	if ran != 2 {
+		t.Errorf("f ran %d times, want once per call", ran)
+	}
+}
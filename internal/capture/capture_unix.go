@@ -0,0 +1,41 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package capture
+
+import (
+	"os"
+	"syscall"
+)
+
+// Stdout and Stderr are the original standard output and error of the process
+// which are not captured.
+var (
+	Stdout = original(syscall.Stdout, os.Stdout)
+	Stderr = original(syscall.Stderr, os.Stderr)
+)
+
+// original duplicates fd so it keeps referring to the original file while fd
+// itself is redirected. f is returned if that fails.
+func original(fd int, f *os.File) *os.File {
+	dup, err := syscall.Dup(fd)
+	if err != nil {
+		return f
+	}
+	syscall.CloseOnExec(dup)
+	return os.NewFile(uintptr(dup), f.Name())
+}
+
+func redirect(w *os.File) (func(), error) {
+	if err := dup2(int(w.Fd()), syscall.Stdout); err != nil {
+		return nil, err
+	}
+	if err := dup2(int(w.Fd()), syscall.Stderr); err != nil {
+		dup2(int(Stdout.Fd()), syscall.Stdout)
+		return nil, err
+	}
+	return func() {
+		dup2(int(Stdout.Fd()), syscall.Stdout)
+		dup2(int(Stderr.Fd()), syscall.Stderr)
+	}, nil
+}
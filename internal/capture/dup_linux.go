@@ -0,0 +1,8 @@
+package capture
+
+import "syscall"
+
+// dup2 uses dup3 since dup2 is not available on every architecture.
+func dup2(oldfd, newfd int) error {
+	return syscall.Dup3(oldfd, newfd, 0)
+}
@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"plugin"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/command"
+	"github.com/aod/elver/config"
+	"github.com/aod/elver/internal/solver"
+)
+
+// buildPlugin builds the solvers of year residing in yPath in plugin build
+// mode and opens the result.
+func buildPlugin(year aoc.Year, yPath string) (*plugin.Plugin, error) {
+	return build(year.String(), yPath)
+}
+
+// buildDayPlugin builds the solvers of the package of a single day residing
+// in dPath in plugin build mode and opens the result.
+func buildDayPlugin(date aoc.Date, dPath string) (*plugin.Plugin, error) {
+	return build(fmt.Sprintf("%s-%02d", date.Year, int(date.Day)), dPath)
+}
+
+// build builds the package in dir to the plugin name in the builds cache
+// unless the plugin is up to date.
+func build(name, dir string) (*plugin.Plugin, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	buildFile := filepath.Join(cacheDir, "builds", name)
+	if !upToDate(buildFile, dir) {
+		err = command.New("go build -buildmode=plugin -o=" + buildFile).Dir(dir).Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return plugin.Open(buildFile)
+}
+
+// upToDate reports whether the plugin buildFile was built after the last
+// change to the package in dir. That is a change to its Go files, to those of
+// the packages of its module it imports, to the go.mod and go.sum files of the
+// module or to elver itself which the plugin has to be built against.
+func upToDate(buildFile, dir string) bool {
+	info, err := os.Stat(buildFile)
+	if err != nil {
+		return false
+	}
+	built := info.ModTime()
+	newer := func(path string) bool {
+		info, err := os.Stat(path)
+		return err != nil || info.ModTime().After(built)
+	}
+
+	exe, err := os.Executable()
+	if err != nil || newer(exe) {
+		return false
+	}
+	root, module, err := findModule(dir)
+	if err != nil || newer(filepath.Join(root, "go.mod")) {
+		return false
+	}
+	if sum := filepath.Join(root, "go.sum"); fileExists(sum) && newer(sum) {
+		return false
+	}
+
+	seen := map[string]bool{dir: true}
+	for queue := []string{dir}; len(queue) > 0; queue = queue[1:] {
+		files, err := filepath.Glob(filepath.Join(queue[0], "*.go"))
+		if err != nil || len(files) == 0 {
+			return false
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			if newer(file) {
+				return false
+			}
+			f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+			if err != nil {
+				return false
+			}
+			for _, imp := range f.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				if !strings.HasPrefix(path, module+"/") {
+					continue
+				}
+				pkg := filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(path, module+"/")))
+				if !seen[pkg] {
+					seen[pkg] = true
+					queue = append(queue, pkg)
+				}
+			}
+		}
+	}
+	return true
+}
+
+// findModule returns the root directory and path of the module containing
+// dir.
+func findModule(dir string) (root, module string, err error) {
+	for root = dir; ; {
+		b, err := ioutil.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			if m := modulePathRe.FindSubmatch(b); m != nil {
+				return root, strings.Trim(string(m[1]), `"`), nil
+			}
+			return "", "", fmt.Errorf("no module path in %s", filepath.Join(root, "go.mod"))
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", "", fmt.Errorf("no go.mod found for %s", dir)
+		}
+		root = parent
+	}
+}
+
+var modulePathRe = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// solverSource looks up the solvers of a year in the plugins they are built
+// into.
+type solverSource interface {
+	// plugin returns the plugin with the solvers of day.
+	plugin(day aoc.Day) (*plugin.Plugin, error)
+	// solvers looks up the solvers of both parts of date like
+	// solver.FromPluginBoth.
+	solvers(date aoc.Date) (solver.LogFunc, solver.LogFunc, error)
+	// prepare builds what is needed to look up the solvers of days ahead of
+	// time.
+	prepare(days []aoc.Day)
+}
+
+// buildSource builds the solvers of dir. A year with the day layout is built
+// per day once the solvers of the day are looked up.
+func buildSource(dir yearDir) (solverSource, error) {
+	if dir.layout == layoutDay {
+		return &dayPlugins{year: dir.year, dirs: dir.days, built: make(map[aoc.Day]*builtPlugin)}, nil
+	}
+	p, err := buildPlugin(dir.year, dir.path)
+	if err != nil {
+		return nil, err
+	}
+	return yearPlugin{p}, nil
+}
+
+// yearPlugin is the plugin of a year with the year layout.
+type yearPlugin struct{ p *plugin.Plugin }
+
+func (y yearPlugin) plugin(aoc.Day) (*plugin.Plugin, error) {
+	return y.p, nil
+}
+
+func (y yearPlugin) solvers(date aoc.Date) (solver.LogFunc, solver.LogFunc, error) {
+	return solver.FromPluginBoth(y.p, date)
+}
+
+func (yearPlugin) prepare([]aoc.Day) {}
+
+// dayPlugins are the plugins of the days of a year with the day layout. A day
+// is built the first time it is used or prepared.
+type dayPlugins struct {
+	year aoc.Year
+	dirs map[aoc.Day]string
+
+	mu    sync.Mutex
+	built map[aoc.Day]*builtPlugin
+}
+
+type builtPlugin struct {
+	once sync.Once
+	p    *plugin.Plugin
+	err  error
+}
+
+// buildError is the failure to build the package of a day, as opposed to a day
+// without solvers it stops the search for solvers.
+type buildError struct {
+	day aoc.Day
+	err error
+}
+
+func (e *buildError) Error() string {
+	return fmt.Sprintf("day %s: %s", e.day, e.err)
+}
+
+func (e *buildError) Unwrap() error {
+	return e.err
+}
+
+func (d *dayPlugins) plugin(day aoc.Day) (*plugin.Plugin, error) {
+	path, ok := d.dirs[day]
+	if !ok {
+		return nil, fmt.Errorf("no directory found for day %s", day)
+	}
+
+	d.mu.Lock()
+	b, ok := d.built[day]
+	if !ok {
+		b = &builtPlugin{}
+		d.built[day] = b
+	}
+	d.mu.Unlock()
+
+	b.once.Do(func() {
+		b.p, b.err = buildDayPlugin(aoc.Date{Year: d.year, Day: day}, path)
+		if b.err != nil {
+			b.err = &buildError{day: day, err: b.err}
+		}
+	})
+	return b.p, b.err
+}
+
+// prepare builds the plugins of days in parallel, at most one per CPU.
+func (d *dayPlugins) prepare(days []aoc.Day) {
+	sem := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for _, day := range days {
+		if _, ok := d.dirs[day]; !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(day aoc.Day) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			d.plugin(day)
+		}(day)
+	}
+	wg.Wait()
+}
+
+func (d *dayPlugins) solvers(date aoc.Date) (solver.LogFunc, solver.LogFunc, error) {
+	p, err := d.plugin(date.Day)
+	if err != nil {
+		return nil, nil, err
+	}
+	return solver.FromDayPluginBoth(p, date)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUpToDate(t *testing.T) {
+	root, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	writeFiles(t, root, map[string]string{
+		"go.mod":               "module example.com/aoc\n\ngo 1.14\n",
+		"2020/day05/main.go":   "package main\n\nimport (\n\t\"fmt\"\n\n\t\"example.com/aoc/util\"\n)\n",
+		"2020/day05/a_test.go": "package main\n",
+		"util/util.go":         "package util\n\nimport \"example.com/aoc/util/grid\"\n",
+		"util/grid/grid.go":    "package grid\n",
+		"other/other.go":       "package other\n",
+	})
+	dir := filepath.Join(root, "2020", "day05")
+	buildFile := filepath.Join(root, "build")
+
+	if upToDate(buildFile, dir) {
+		t.Fatal("a missing plugin is up to date")
+	}
+
+	future := time.Now().Add(time.Hour)
+	if err := ioutil.WriteFile(buildFile, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(buildFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if !upToDate(buildFile, dir) {
+		t.Fatal("a plugin built after its sources is not up to date")
+	}
+
+	later := future.Add(time.Hour)
+	for _, tt := range []struct {
+		file  string
+		stale bool
+	}{
+		{"2020/day05/a_test.go", false},
+		{"other/other.go", false},
+		{"util/grid/grid.go", true},
+		{"go.mod", true},
+		{"2020/day05/main.go", true},
+	} {
+		path := filepath.Join(root, tt.file)
+		if err := os.Chtimes(path, later, later); err != nil {
+			t.Fatal(err)
+		}
+		if got := !upToDate(buildFile, dir); got != tt.stale {
+			t.Errorf("after changing %s stale = %t, want %t", tt.file, got, tt.stale)
+		}
+		if err := os.Chtimes(path, time.Now(), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
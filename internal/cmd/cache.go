@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aod/elver/internal/util"
+)
+
+// fetchCached returns the contents of the cache file path if it was written
+// less than maxAge ago. Otherwise the request created by newReq is executed and
+// its response body is written to path once validate accepts it, so a login
+// page or an error page is not cached.
+func fetchCached(path string, maxAge time.Duration, newReq func() (*http.Request, error), validate func([]byte) error) ([]byte, error) {
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < maxAge {
+		return ioutil.ReadFile(path)
+	}
+
+	req, err := newReq()
+	if err != nil {
+		return nil, err
+	}
+	body, err := util.Fetch(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate(body); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0744); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(path, body, 0644); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFetchCached(t *testing.T) {
+	bodies := []string{"<html>login</html>", "ok"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, bodies[0])
+		bodies = bodies[1:]
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cache", "page")
+
+	newReq := func() (*http.Request, error) { return http.NewRequest("GET", srv.URL, nil) }
+	validate := func(b []byte) error {
+		if string(b) != "ok" {
+			return errors.New("invalid")
+		}
+		return nil
+	}
+
+	if _, err := fetchCached(path, time.Hour, newReq, validate); err == nil {
+		t.Fatal("expected the invalid body to be rejected")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("invalid body was cached: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		b, err := fetchCached(path, time.Hour, newReq, validate)
+		if err != nil || string(b) != "ok" {
+			t.Fatalf("fetchCached = %q, %v, want ok", b, err)
+		}
+	}
+	if len(bodies) != 0 {
+		t.Errorf("the cached body was fetched again")
+	}
+}
@@ -1,129 +1,623 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
-	"path/filepath"
-	"plugin"
 	"strings"
+	"sync"
+	"time"
 	"unsafe"
 
+	"github.com/aod/elver/internal/capture"
 	"github.com/aod/elver/internal/solver"
 	"github.com/aod/elver/internal/util"
 
 	"github.com/aod/elver/aoc"
-	"github.com/aod/elver/command"
 	"github.com/aod/elver/config"
 	"github.com/aod/elver/flags"
+	"github.com/aod/elver/viz"
 )
 
+// subcommands maps the name of a subcommand to its implementation which is
+// given the remaining arguments.
+var subcommands = map[string]func(args []string) error{
+	"login":       loginCmd,
+	"logout":      logoutCmd,
+	"whoami":      whoamiCmd,
+	"leaderboard": leaderboardCmd,
+	"status":      statusCmd,
+	"list":        listCmd,
+	"viz":         vizCmd,
+}
+
+// parseArgs parses args with fs allowing flags to appear after positional
+// arguments. The positional arguments are returned.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return pos, nil
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// clockEnv is the environment variable which, set to an RFC 3339 timestamp,
+// makes elver pretend it is that time. The clock keeps ticking from there.
+const clockEnv = "ELVER_NOW"
+
+func setClock() error {
+	v, ok := os.LookupEnv(clockEnv)
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", clockEnv, err)
+	}
+	if _, ok := aoc.LastYearAt(t); !ok {
+		first := aoc.Date{Year: aoc.FirstYear, Day: aoc.FirstDay}.UnlockTime()
+		return fmt.Errorf("invalid %s: %s is before the first Advent of Code unlocked at %s",
+			clockEnv, v, first.Format(time.RFC3339))
+	}
+
+	start := time.Now()
+	aoc.Clock = func() time.Time { return t.Add(time.Since(start)) }
+	return nil
+}
+
 // Execute is the entrypoint to elver.
 func Execute(args []string) {
+	config.SetAppName("elver")
+	util.HandleError(setClock())
+
+	if len(args) > 1 {
+		if sub, ok := subcommands[args[1]]; ok {
+			util.HandleError(sub(args[2:]))
+			return
+		}
+	}
+
 	benchmarkFlag := flag.Bool("b", false, "enable benchmarking")
 	testFlag := flag.Bool("t", false, "enable testing")
+	jobsFlag := flag.Int("j", 1, "the `number` of solvers to run in parallel, benchmarks always run serially")
+	verboseFlag := flag.Bool("v", false, "show the debug output solvers write to their logger")
+	outputFlag := flag.String("output", "", "what to do with the output of solvers: show, hide, save or off, defaults to show or off with -j")
+	runsFlag := flag.Int("runs", 1, "time every solver `n` times and report the min, median and max")
+	warmupFlag := flag.Int("warmup", 0, "run every solver `n` times before timing it")
+	determinismFlag := flag.Bool("check-determinism", false, "run every solver multiple times and report parts whose answer varies")
+	determinismRunsFlag := flag.Int("determinism-runs", 10, "the `number` of runs when checking determinism")
+	varyRuntimeFlag := flag.Bool("vary-runtime", false, "vary GOMAXPROCS and the GC percentage between runs when checking determinism")
+	vizOutFlag := flag.String("viz-out", "", "record the frames emitted by solvers to a .gif or .png `file`")
+	vizCellFlag := flag.Int("viz-cell", 8, "the size of a recorded cell in `pixels`")
+	vizPaletteFlag := flag.String("viz-palette", "terminal", "the `palette` of recorded frames, terminal or gray followed by colors like bg=ffffff,red=ff0000")
+	vizDelayFlag := flag.Duration("viz-delay", 100*time.Millisecond, "the `delay` between frames of a recorded GIF")
+	vizSkipFlag := flag.Int("viz-skip", 0, "the `number` of frames to skip after every frame of a recorded GIF")
+	layoutFlag := flag.String("layout", "auto", "the `layout` of the year directories: year for a package per year, day for a package per day like 2020/day05 or auto")
+	inputsFlag := flag.String("inputs", "", "run against every input found in `dir` and its sub directories")
+	inputsCachedFlag := flag.Bool("inputs-cached", false, "run against every cached input including other accounts")
+	accountFlag := flag.String("account", "", "download and cache the inputs of the session as those of the other account `name` for -inputs-cached")
 
-	year := &flags.IntRange{Value: 0, Min: int(aoc.FirstYear), Max: int(aoc.LastYear())}
-	flag.Var(year, "y", "the `year` to run")
+	year := &flags.IntSet{Min: int(aoc.FirstYear), Max: int(aoc.LastYear())}
+	flag.Var(year, "y", "the `years` to run, e.g. 2019, 2015-2017,2020 or all")
 
-	day := &flags.IntRange{Value: 0, Min: int(aoc.FirstDay), Max: int(aoc.LastDay)}
-	flag.Var(day, "d", "the `day` to run")
+	day := &flags.IntSet{Min: int(aoc.FirstDay), Max: int(aoc.LastDay)}
+	flag.Var(day, "d", "the `days` to run, e.g. 5, 1-5,7,20-, !3 or all")
 
-	flag.Parse()
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: elver [flags] [date]")
+		fmt.Fprintln(flag.CommandLine.Output(), "The date selects the year, day and optionally part to run, e.g. 2020/5 or 2020/5b.")
+		flag.PrintDefaults()
+	}
 
-	cwd, err := os.Getwd()
+	pos, err := parseArgs(flag.CommandLine, args[1:])
+	util.HandleError(err)
+	part, err := dateArg(pos, year, day)
 	util.HandleError(err)
 
-	config.SetAppName("elver")
-	sessReader, err := config.EnvOrContents("AOC_SESSION", "aoc_session")
+	cwd, err := os.Getwd()
 	util.HandleError(err)
-	b, err := ioutil.ReadAll(sessReader)
+	proj, err := findProject(cwd)
+	util.HandleError(err)
+
+	// Inside the directory of a year only its solvers run, of the day being
+	// worked on when it is known.
+	if year.Empty() && proj.year != 0 {
+		util.HandleError(year.Set(proj.year.String()))
+		if day.Empty() && proj.day != 0 {
+			util.HandleError(day.Set(proj.day.String()))
+		}
+	}
+
+	years, days := year.Values(), day.Values()
+
+	sessionID, err := readSession()
 	util.HandleError(err)
-	sessionID := strings.TrimSpace(*(*string)(unsafe.Pointer(&b)))
 
 	var dirFinder yearDirFinder = latestYearDirFinder{}
-	if year.Value != 0 {
-		dirFinder = specificYearDirFinder{year: aoc.Year(year.Value)}
+	switch {
+	case len(years) == 1:
+		dirFinder = specificYearDirFinder{year: aoc.Year(years[0])}
+	case len(years) > 1:
+		set := yearSetDirFinder{}
+		for _, y := range years {
+			set.years = append(set.years, aoc.Year(y))
+		}
+		dirFinder = set
 	}
 
+	// Running multiple years runs all of their solvers unless days are
+	// selected.
+	if len(years) > 1 && day.Empty() {
+		day.Set("all")
+		days = day.Values()
+	}
+
+	// Selecting all days selects those of each event, any other selection
+	// has to be part of the events of all selected years.
+	selected := make([]aoc.Day, len(days))
+	for i, d := range days {
+		selected[i] = aoc.Day(d)
+	}
 	var solversFinder solversFinder = latestSolversFinder{}
-	if day.Value != 0 {
-		solversFinder = specificDaySolversFinder{day: aoc.Day(day.Value)}
+	switch {
+	case len(selected) == int(aoc.LastDay):
+		solversFinder = daySetSolversFinder{all: true}
+	case len(selected) == 1:
+		solversFinder = specificDaySolversFinder{day: selected[0]}
+	case len(selected) > 1:
+		solversFinder = daySetSolversFinder{days: selected}
+	}
+	if len(selected) < int(aoc.LastDay) {
+		for _, y := range years {
+			util.HandleError(checkDays(aoc.Year(y), selected))
+		}
+	}
+
+	output, err := parseOutputMode(*outputFlag, *jobsFlag)
+	util.HandleError(err)
+	layout, err := parseLayout(*layoutFlag)
+	util.HandleError(err)
+	set, err := defaultInputSet()
+	util.HandleError(err)
+	if *accountFlag != "" {
+		if *inputsFlag != "" || *inputsCachedFlag {
+			util.HandleError(errors.New("-account can not be combined with -inputs and -inputs-cached"))
+		}
+		set, err = accountInputSet(*accountFlag)
+		util.HandleError(err)
+	}
+	if *runsFlag < 1 {
+		util.HandleError(errors.New("-runs must be at least 1"))
+	}
+	if *warmupFlag < 0 {
+		util.HandleError(errors.New("-warmup can not be negative"))
+	}
+	if *determinismFlag {
+		switch {
+		case *determinismRunsFlag < 2:
+			util.HandleError(errors.New("-determinism-runs must be at least 2"))
+		case *benchmarkFlag:
+			util.HandleError(errors.New("-check-determinism can not be combined with -b"))
+		case *inputsFlag != "" || *inputsCachedFlag:
+			util.HandleError(errors.New("-check-determinism can not be combined with -inputs and -inputs-cached"))
+		}
+	}
+
+	palette, err := viz.ParsePalette(*vizPaletteFlag)
+	util.HandleError(err)
+	vo := vizOut{path: *vizOutFlag, opts: viz.ImageOptions{
+		CellSize: *vizCellFlag,
+		Palette:  palette,
+		Delay:    *vizDelayFlag,
+		Skip:     *vizSkipFlag,
+	}}
+	if vo.path != "" {
+		util.HandleError(vo.validate())
+		switch {
+		case *benchmarkFlag, *determinismFlag, *runsFlag > 1, *warmupFlag > 0:
+			util.HandleError(errors.New("-viz-out records a single run and can not be combined with -b, -check-determinism, -runs or -warmup"))
+		case *jobsFlag > 1:
+			util.HandleError(errors.New("-viz-out can not be combined with -j"))
+		case *inputsFlag != "" || *inputsCachedFlag:
+			util.HandleError(errors.New("-viz-out can not be combined with -inputs and -inputs-cached"))
+		}
+	}
+
+	opts := options{
+		cwd:             cwd,
+		root:            proj.root,
+		sessionID:       sessionID,
+		benchmark:       *benchmarkFlag,
+		test:            *testFlag,
+		part:            part,
+		jobs:            *jobsFlag,
+		verbose:         *verboseFlag,
+		output:          output,
+		runs:            *runsFlag,
+		warmup:          *warmupFlag,
+		determinism:     *determinismFlag,
+		determinismRuns: *determinismRunsFlag,
+		varyRuntime:     *varyRuntimeFlag,
+		layout:          layout,
+		inputSet:        set,
+		inputsDir:       *inputsFlag,
+		inputsCached:    *inputsCachedFlag,
+	}
+	util.HandleError(vo.record(func() error {
+		return run(opts, dirFinder, solversFinder)
+	}))
+}
+
+// dateArg parses the optional positional date argument, e.g. 2020/5 or
+// 2020/5b, into the year and day flags. The part is returned when given.
+func dateArg(pos []string, year, day *flags.IntSet) (aoc.Part, error) {
+	switch {
+	case len(pos) == 0:
+		return 0, nil
+	case len(pos) > 1:
+		return 0, fmt.Errorf("unexpected arguments: %s", strings.Join(pos[1:], " "))
+	case !year.Empty() || !day.Empty():
+		return 0, errors.New("a date argument can not be combined with the -y and -d flags")
+	}
+
+	dp, err := aoc.ParseDatePart(pos[0])
+	if err != nil {
+		d, err := aoc.ParseDate(pos[0])
+		if err != nil {
+			return 0, err
+		}
+		dp = aoc.DatePart{Date: d}
 	}
 
-	opts := options{cwd, sessionID, *benchmarkFlag, *testFlag}
-	util.HandleError(run(opts, dirFinder, solversFinder))
+	if err := year.Set(dp.Year.String()); err != nil {
+		return 0, err
+	}
+	if err := day.Set(dp.Day.String()); err != nil {
+		return 0, err
+	}
+	return dp.Part, nil
 }
 
 type options struct {
+	// cwd is the working directory and root the directory of the years.
 	cwd       string
+	root      string
 	sessionID string
 	benchmark bool
 	test      bool
+
+	// part is the only part to run when set.
+	part aoc.Part
+	// jobs is the number of solvers to run in parallel.
+	jobs int
+	// verbose shows the debug output of solvers instead of only its size.
+	verbose bool
+	// output decides what happens with the output of solvers.
+	output outputMode
+	// runs and warmup are the number of timed and untimed runs of a solver
+	// when it is not benchmarked.
+	runs   int
+	warmup int
+	// determinism checks whether the solvers give the same answer in every
+	// one of determinismRuns runs, varying the runtime configuration when
+	// varyRuntime is set.
+	determinism     bool
+	determinismRuns int
+	varyRuntime     bool
+	// layout is how the solvers are organized in the year directories.
+	layout layout
+	// inputSet is where the inputs of the session are cached.
+	inputSet inputSet
+
+	inputsDir    string
+	inputsCached bool
 }
 
-func run(opts options, dirFinder yearDirFinder, solversFinder solversFinder) error {
-	year, yPath, err := dirFinder.findYearDir(opts.cwd)
-	if err != nil {
-		return err
+// resultKind returns how the solvers are measured.
+func (opts options) resultKind() solver.ResultKind {
+	if opts.benchmark {
+		return solver.BenchmarkResult
+	}
+	return solver.TimeResult
+}
+
+// solver returns the solver of dp which runs f as configured by opts.
+func (opts options) solver(dp aoc.DatePart, f solver.LogFunc) solver.Solver {
+	return solver.Solver{
+		DatePart: dp,
+		Solver:   f,
+		Warmup:   opts.warmup,
+		Runs:     opts.runs,
+		Capture:  opts.output != outputOff,
 	}
+}
 
-	cacheDir, err := config.CacheDir()
+// usesInputSets reports whether the solvers run against input sets instead of
+// the input of the current session.
+func (opts options) usesInputSets() bool {
+	return opts.inputsDir != "" || opts.inputsCached
+}
+
+// inputSets returns all input sets selected by the -inputs and -inputs-cached
+// flags.
+func (opts options) inputSets() ([]inputSet, error) {
+	var sets []inputSet
+	if opts.inputsCached {
+		cached, err := cachedInputSets()
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, cached...)
+	}
+	if opts.inputsDir != "" {
+		dir, err := dirInputSets(opts.inputsDir)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, dir...)
+	}
+	return sets, nil
+}
+
+func run(opts options, dirFinder yearDirFinder, solversFinder solversFinder) error {
+	dirs, err := dirFinder.findYearDirs(opts.root)
 	if err != nil {
 		return err
 	}
-	buildFile := filepath.Join(cacheDir, "builds", year.String())
-	err = command.New("go build -buildmode=plugin -o=" + buildFile).Dir(yPath).Exec()
-	if err != nil {
+	if err := withLayouts(dirs, opts.layout); err != nil {
 		return err
 	}
+	discoveries := make([]solver.Discovery, len(dirs))
+	for i, dir := range dirs {
+		if discoveries[i], err = dir.discover(); err != nil {
+			return err
+		}
+		printWarnings(os.Stderr, opts.cwd, discoveries[i].Warnings)
+	}
+	sources, buildErrs := buildSources(dirs)
 
-	p, err := plugin.Open(buildFile)
-	if err != nil {
+	// Selecting multiple years, e.g. with -y all, runs them all and ends with
+	// a summary, even when only a single one of them has a directory. A year
+	// which fails is reported and skipped so all other years still run, the
+	// failure is returned after the summary.
+	_, multiple := dirFinder.(yearSetDirFinder)
+	summaries := make([]yearSummary, 0, len(dirs))
+	for i, dir := range dirs {
+		fmt.Println("AOC", dir.year)
+		ys := yearSummary{year: dir.year, err: buildErrs[i]}
+		if ys.err == nil {
+			ys.results, ys.err = runYear(opts, dir, discoveries[i], sources[i], solversFinder)
+		}
+		if !multiple {
+			return ys.err
+		}
+		if ys.err != nil {
+			fmt.Printf("[ERROR] %s\n", ys.err)
+		}
+		summaries = append(summaries, ys)
+		fmt.Println()
+	}
+	if opts.usesInputSets() || opts.determinism {
+		return failedYears(summaries)
+	}
+	if err := printSummary(os.Stdout, summaries); err != nil {
 		return err
 	}
+	return failedYears(summaries)
+}
 
-	day, funcA, funcB, err := solversFinder.findSolvers(p)
-	if err != nil {
-		return fmt.Errorf("%s: %w", year, err)
+// buildSources concurrently builds the solvers of all dirs. The sources and
+// errors are returned in the order of dirs.
+func buildSources(dirs []yearDir) ([]solverSource, []error) {
+	sources := make([]solverSource, len(dirs))
+	errs := make([]error, len(dirs))
+
+	var wg sync.WaitGroup
+	for i, dir := range dirs {
+		wg.Add(1)
+		go func(i int, dir yearDir) {
+			defer wg.Done()
+			sources[i], errs[i] = buildSource(dir)
+		}(i, dir)
 	}
+	wg.Wait()
 
-	date := aoc.Date{Year: year, Day: day}
-	b, err := getInput(date, opts.sessionID)
+	return sources, errs
+}
+
+func runYear(opts options, dir yearDir, found solver.Discovery, src solverSource, solversFinder solversFinder) ([]solver.Result, error) {
+	year := dir.year
+	days, err := solversFinder.findSolvers(src, year)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("%s: %w", year, err)
 	}
-	input := *(*string)(unsafe.Pointer(&b))
 
-	k := solver.TimeResult
-	if opts.benchmark {
-		k = solver.BenchmarkResult
+	if opts.usesInputSets() {
+		sets, err := opts.inputSets()
+		if err != nil {
+			return nil, err
+		}
+		// Every day runs so that all wrong answers are shown, the first
+		// failure is returned.
+		var failed error
+		for _, ds := range days {
+			date := aoc.Date{Year: year, Day: ds.day}
+			funcA, funcB, err := opts.selectParts(date, ds)
+			if err != nil {
+				return nil, err
+			}
+			err = runInputSets(os.Stdout, opts, sets, date, [2]solver.LogFunc{funcA, funcB})
+			if err != nil && failed == nil {
+				failed = err
+			}
+		}
+		return nil, failed
 	}
 
-	fmt.Println("AOC", year)
+	if err := findVariants(src, found, year, days); err != nil {
+		return nil, err
+	}
+	var jobs []job
+	for _, ds := range days {
+		dayJobs, err := dayJobs(opts, aoc.Date{Year: year, Day: ds.day}, ds)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, dayJobs...)
+	}
 
-	solverA := solver.Solver{
-		DatePart: aoc.DatePart{
-			Date: date,
-			Part: aoc.Part1,
-		},
-		Solver: funcA,
+	if opts.determinism {
+		return nil, checkDeterminism(os.Stdout, opts, jobs)
 	}
-	fmt.Fprintln(os.Stdout, solverA.Result(input, k))
 
-	if funcB == nil {
-		return nil
+	// Other solvers may be capturing the output while a result is printed.
+	w := capture.Stdout
+	outputs := make(outputs)
+	var results []solver.Result
+	// part holds the results of the solver of the current part followed by
+	// those of its variants.
+	var part []solver.Result
+	err = runJobs(jobs, opts.resultKind(), opts.jobs, func(j job, r solver.Result) error {
+		if r.Variant == "" {
+			part = part[:0]
+		} else if len(part) > 0 {
+			r = checkVariant(part[0], r)
+		}
+		part = append(part, r)
+
+		fmt.Fprintln(w, r)
+		printLog(w, r.Log, opts.verbose)
+		if err := outputs.handle(w, opts.output, r); err != nil {
+			return err
+		}
+		if j.compare && opts.benchmark {
+			if err := printComparison(w, part); err != nil {
+				return err
+			}
+		}
+		if j.note != "" {
+			fmt.Fprintln(w, j.note)
+		}
+		if r.Variant != "" {
+			return nil
+		}
+		results = append(results, r)
+		return saveTiming(r)
+	})
+	return results, err
+}
+
+// printLog prints the debug output of a solver collapsed to its number of
+// lines, or in full when verbose.
+func printLog(w io.Writer, log string, verbose bool) {
+	if log == "" {
+		return
 	}
+	lines := strings.Split(strings.TrimSuffix(log, "\n"), "\n")
+	if !verbose {
+		noun := "lines"
+		if len(lines) == 1 {
+			noun = "line"
+		}
+		fmt.Fprintf(w, "> log: %d %s, use -v to show\n", len(lines), noun)
+		return
+	}
+	fmt.Fprintln(w, "v log:")
+	for _, line := range lines {
+		fmt.Fprintln(w, "|", line)
+	}
+}
 
-	solverB := solver.Solver{
-		DatePart: aoc.DatePart{
-			Date: date,
-			Part: aoc.Part2,
-		},
-		Solver: funcB,
+// checkDeterminism runs every job multiple times, one after the other since
+// the runtime configuration is process wide, and reports the distinct answers
+// of the jobs that are not deterministic.
+func checkDeterminism(w io.Writer, opts options, jobs []job) error {
+	var vs []solver.Variation
+	if opts.varyRuntime {
+		vs = solver.Variations()
 	}
-	fmt.Fprintln(os.Stdout, solverB.Result(input, k))
 
+	n := 0
+	for _, j := range jobs {
+		d := j.solver.CheckDeterminism(j.input, opts.determinismRuns, vs)
+		fmt.Fprintln(w, d)
+		if j.note != "" {
+			fmt.Fprintln(w, j.note)
+		}
+		if !d.Deterministic() {
+			n++
+		}
+	}
+	if n > 0 {
+		return fmt.Errorf("%d of %d parts are not deterministic", n, len(jobs))
+	}
 	return nil
 }
+
+// selectParts returns the solvers of ds for date limited to the selected
+// part. Selecting a part which has no solver is an error.
+func (opts options) selectParts(date aoc.Date, ds daySolvers) (solver.LogFunc, solver.LogFunc, error) {
+	a, b := ds.a, ds.b
+	switch opts.part {
+	case aoc.Part1:
+		b = nil
+	case aoc.Part2:
+		a = nil
+	}
+	if opts.part != 0 && a == nil && b == nil {
+		return nil, nil, fmt.Errorf("no solver for %s day %s part %s", date.Year, date.Day, opts.part)
+	}
+	return a, b, nil
+}
+
+// dayJobs returns the jobs which run the solvers of date against its input.
+func dayJobs(opts options, date aoc.Date, ds daySolvers) ([]job, error) {
+	singlePart := aoc.Event(date.Year).SinglePart(date.Day)
+	if singlePart && opts.part == aoc.Part2 {
+		return nil, fmt.Errorf("%s: the final day only has a single puzzle", date)
+	}
+
+	funcA, funcB, err := opts.selectParts(date, ds)
+	if err != nil {
+		return nil, err
+	}
+	if singlePart {
+		funcB = nil
+	}
+	b, err := getInput(opts.inputSet, date, opts.sessionID)
+	if err != nil {
+		return nil, err
+	}
+	input := *(*string)(unsafe.Pointer(&b))
+
+	var jobs []job
+	for i, f := range []solver.LogFunc{funcA, funcB} {
+		if f == nil {
+			continue
+		}
+		dp := aoc.DatePart{Date: date, Part: aoc.Part1 + aoc.Part(i)}
+		jobs = append(jobs, job{solver: opts.solver(dp, f), input: input})
+
+		for _, v := range ds.variants[i] {
+			s := opts.solver(dp, v.Solver)
+			s.Variant = v.Name
+			jobs = append(jobs, job{solver: s, input: input})
+		}
+		if len(ds.variants[i]) > 0 {
+			jobs[len(jobs)-1].compare = true
+		}
+	}
+
+	if singlePart && opts.part == 0 && len(jobs) > 0 {
+		jobs[len(jobs)-1].note = fmt.Sprintf("Day %s has no part %s, the final day only has a single puzzle", date.Day, aoc.Part2)
+	}
+	return jobs, nil
+}
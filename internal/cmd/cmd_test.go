@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/internal/solver"
+	"github.com/aod/elver/logger"
+)
+
+func TestSelectParts(t *testing.T) {
+	f := func(string, *logger.Logger) (interface{}, error) { return nil, nil }
+	date := aoc.Date{Year: 2020, Day: 5}
+	ds := daySolvers{day: 5, a: f}
+
+	for _, part := range []aoc.Part{0, aoc.Part1} {
+		a, b, err := options{part: part}.selectParts(date, ds)
+		if err != nil || a == nil || b != nil {
+			t.Errorf("part %q: got a=%v b=%v err=%v", part, a != nil, b != nil, err)
+		}
+	}
+
+	_, _, err := options{part: aoc.Part2}.selectParts(date, ds)
+	if err == nil {
+		t.Fatal("selecting part B without a solver succeeded")
+	}
+	if want := "no solver for 2020 day 5 part B"; err.Error() != want {
+		t.Errorf("err = %q, want %q", err, want)
+	}
+}
+
+func TestDayJobsSinglePart(t *testing.T) {
+	defer func(c func() time.Time) { aoc.Clock = c }(aoc.Clock)
+	aoc.Clock = func() time.Time { return time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC) }
+
+	dir, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{
+		"2020/25.txt": "input",
+		"2025/12.txt": "input",
+	})
+	set := inputSet{name: "test", dir: dir}
+
+	f := func(string, *logger.Logger) (interface{}, error) { return nil, nil }
+	for _, date := range []aoc.Date{{Year: 2020, Day: 25}, {Year: 2025, Day: 12}} {
+		// A part B solver of the final day is never run.
+		ds := daySolvers{day: date.Day, a: f, b: f}
+
+		jobs, err := dayJobs(options{inputSet: set}, date, ds)
+		if err != nil {
+			t.Fatalf("%s: %v", date, err)
+		}
+		if len(jobs) != 1 || jobs[0].solver.Part != aoc.Part1 {
+			t.Fatalf("%s: got %d jobs, want a single job of part A", date, len(jobs))
+		}
+		want := "Day " + date.Day.String() + " has no part B, the final day only has a single puzzle"
+		if jobs[0].note != want {
+			t.Errorf("%s: note = %q, want %q", date, jobs[0].note, want)
+		}
+
+		_, err = dayJobs(options{inputSet: set, part: aoc.Part2}, date, ds)
+		if err == nil || !strings.Contains(err.Error(), "the final day only has a single puzzle") {
+			t.Errorf("%s: part B err = %v, want a single puzzle error", date, err)
+		}
+	}
+}
+
+func TestSetClockBeforeFirstEvent(t *testing.T) {
+	defer func(c func() time.Time) { aoc.Clock = c }(aoc.Clock)
+	defer setenv(clockEnv, "2010-01-01T00:00:00Z")()
+
+	if err := setClock(); err == nil || !strings.Contains(err.Error(), "before the first Advent of Code") {
+		t.Errorf("setClock() = %v, want an error for a clock before 2015", err)
+	}
+
+	os.Setenv(clockEnv, "2020-12-05T05:00:00Z")
+	if err := setClock(); err != nil {
+		t.Fatal(err)
+	}
+	if got := aoc.LastYear(); got != 2020 {
+		t.Errorf("LastYear() = %d, want 2020", got)
+	}
+}
+
+func TestFailedYears(t *testing.T) {
+	years := []yearSummary{{year: 2019}, {year: 2020}, {year: 2021}}
+	if err := failedYears(years); err != nil {
+		t.Errorf("failedYears() = %v, want nil", err)
+	}
+
+	years[0].err = errors.New("build failed")
+	years[2].err = errors.New("no solvers")
+	err := failedYears(years)
+	if want := "2 of 3 years failed: 2019, 2021"; err == nil || err.Error() != want {
+		t.Errorf("failedYears() = %v, want %q", err, want)
+	}
+}
+
+// fakeSource has a solver for part A of every day.
+type fakeSource struct{ prepared []aoc.Day }
+
+func (s *fakeSource) plugin(aoc.Day) (*solver.Plugin, error) { return nil, errors.New("no plugin") }
+func (s *fakeSource) prepare(days []aoc.Day)                 { s.prepared = days }
+func (s *fakeSource) solvers(aoc.Date) (solver.LogFunc, solver.LogFunc, error) {
+	return func(string, *logger.Logger) (interface{}, error) { return nil, nil }, nil, nil
+}
+
+func TestDaySetSolversFinder(t *testing.T) {
+	src := &fakeSource{}
+	if _, err := (daySetSolversFinder{days: []aoc.Day{11, 12, 13}}).findSolvers(src, 2025); err == nil ||
+		err.Error() != "2025 only has 12 days, got day 13" {
+		t.Errorf("days outside the event: err = %v", err)
+	}
+
+	days, err := daySetSolversFinder{all: true}.findSolvers(src, 2025)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 12 || len(src.prepared) != 12 {
+		t.Errorf("all days of 2025: found %d, prepared %d, want 12", len(days), len(src.prepared))
+	}
+	if days, _ := (daySetSolversFinder{all: true}).findSolvers(src, 2020); len(days) != 25 {
+		t.Errorf("all days of 2020: found %d, want 25", len(days))
+	}
+}
@@ -3,64 +3,177 @@ package cmd
 import (
 	"errors"
 	"fmt"
-	"plugin"
 	"sort"
 
 	"github.com/aod/elver/aoc"
 	"github.com/aod/elver/internal/solver"
 )
 
+// yearDir is an Advent of Code year and the path of its directory. The layout
+// and, with the day layout, the directories of the days are set by
+// withLayout.
+type yearDir struct {
+	year   aoc.Year
+	path   string
+	layout layout
+	days   map[aoc.Day]string
+}
+
 type yearDirFinder interface {
-	findYearDir(string) (aoc.Year, string, error)
+	findYearDirs(string) ([]yearDir, error)
 }
 
 type latestYearDirFinder struct{}
 
-func (latestYearDirFinder) findYearDir(cwd string) (aoc.Year, string, error) {
+func (latestYearDirFinder) findYearDirs(cwd string) ([]yearDir, error) {
 	years := aoc.Years()
 	sort.Sort(sort.Reverse(years))
 	year, path, err := years.FirstYearDir(cwd)
 	if err != nil {
-		return 0, "", fmt.Errorf("no advent year directory found in %s: %w", cwd, err)
+		return nil, fmt.Errorf("no advent year directory found in %s: %w", cwd, err)
 	}
-	return year, path, nil
+	return []yearDir{{year: year, path: path}}, nil
 }
 
 type specificYearDirFinder struct{ year aoc.Year }
 
-func (f specificYearDirFinder) findYearDir(cwd string) (aoc.Year, string, error) {
+func (f specificYearDirFinder) findYearDirs(cwd string) ([]yearDir, error) {
 	p, err := f.year.FindDir(cwd)
 	if err != nil {
-		return 0, "", fmt.Errorf("no advent year %d directory found in %s: %w", f.year, cwd, err)
+		return nil, fmt.Errorf("no advent year %d directory found in %s: %w", f.year, cwd, err)
+	}
+	return []yearDir{{year: f.year, path: p}}, nil
+}
+
+// yearSetDirFinder finds the directories of all years which exist. Years
+// without a directory are skipped.
+type yearSetDirFinder struct{ years []aoc.Year }
+
+func (f yearSetDirFinder) findYearDirs(cwd string) ([]yearDir, error) {
+	var dirs []yearDir
+	for _, y := range f.years {
+		if p, err := y.FindDir(cwd); err == nil {
+			dirs = append(dirs, yearDir{year: y, path: p})
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no advent year directory of %v found in %s", f.years, cwd)
 	}
-	return f.year, p, nil
+	return dirs, nil
+}
+
+// daySolvers holds the solvers of both parts of a day. Solver b is nil when
+// part B has no solver. The variants of the parts are set by findVariants.
+type daySolvers struct {
+	day      aoc.Day
+	a, b     solver.LogFunc
+	variants [2][]solver.Variant
 }
 
 type solversFinder interface {
-	findSolvers(p *plugin.Plugin) (aoc.Day, solver.Func, solver.Func, error)
+	findSolvers(src solverSource, year aoc.Year) ([]daySolvers, error)
+}
+
+// fatal reports whether err stops the search for solvers instead of skipping
+// the day.
+func fatal(err error) bool {
+	var be *buildError
+	return errors.Is(err, solver.ErrSolverInvalidSignature) || errors.As(err, &be)
 }
 
 type latestSolversFinder struct{}
 
-func (latestSolversFinder) findSolvers(p *plugin.Plugin) (aoc.Day, solver.Func, solver.Func, error) {
-	for day := aoc.LastDay; day >= aoc.FirstDay; day-- {
-		a, b, err := solver.FromPluginBoth(p, day)
-		if errors.Is(err, solver.ErrSolverInvalidSignature) {
-			return day, nil, nil, err
+func (latestSolversFinder) findSolvers(src solverSource, year aoc.Year) ([]daySolvers, error) {
+	for day := aoc.Event(year).LastDay(); day >= aoc.FirstDay; day-- {
+		a, b, err := src.solvers(aoc.Date{Year: year, Day: day})
+		if fatal(err) {
+			return nil, err
 		} else if a == nil && b == nil && err != nil { // no solvers found for day, keep looping
 			continue
 		}
-		return day, a, b, nil
+		return []daySolvers{{day: day, a: a, b: b}}, nil
 	}
-	return 0, nil, nil, fmt.Errorf("no solvers found")
+	return nil, fmt.Errorf("no solvers found")
 }
 
 type specificDaySolversFinder struct{ day aoc.Day }
 
-func (f specificDaySolversFinder) findSolvers(p *plugin.Plugin) (aoc.Day, solver.Func, solver.Func, error) {
-	a, b, err := solver.FromPluginBoth(p, f.day)
+func (f specificDaySolversFinder) findSolvers(src solverSource, year aoc.Year) ([]daySolvers, error) {
+	date := aoc.Date{Year: year, Day: f.day}
+	if err := date.Validate(); err != nil {
+		return nil, err
+	}
+	a, b, err := src.solvers(date)
 	if err != nil {
-		return f.day, nil, nil, err
+		return nil, err
+	}
+	return []daySolvers{{day: f.day, a: a, b: b}}, nil
+}
+
+// checkDays returns an error when one of days is not part of the event held
+// in year.
+func checkDays(year aoc.Year, days []aoc.Day) error {
+	event := aoc.Event(year)
+	for _, day := range days {
+		if !event.Has(day) {
+			return fmt.Errorf("%s only has %d days, got day %s", year, event.LastDay(), day)
+		}
+	}
+	return nil
+}
+
+// daySetSolversFinder finds the solvers of all days which have them. Days
+// without solvers are skipped, selecting a day which is not part of the event
+// is an error. With all set every day of the event is searched instead.
+type daySetSolversFinder struct {
+	days []aoc.Day
+	all  bool
+}
+
+func (f daySetSolversFinder) findSolvers(src solverSource, year aoc.Year) ([]daySolvers, error) {
+	days := f.days
+	if f.all {
+		days = aoc.Event(year).Days()
+	} else if err := checkDays(year, days); err != nil {
+		return nil, err
+	}
+
+	var found []daySolvers
+	src.prepare(days)
+	for _, day := range days {
+		a, b, err := src.solvers(aoc.Date{Year: year, Day: day})
+		if fatal(err) {
+			return nil, err
+		} else if err != nil {
+			continue
+		}
+		found = append(found, daySolvers{day: day, a: a, b: b})
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("no solvers found for days %v", days)
+	}
+	return found, nil
+}
+
+// findVariants sets the variants of the parts of days which have a solver as
+// declared in found.
+func findVariants(src solverSource, found solver.Discovery, year aoc.Year, days []daySolvers) error {
+	for i := range days {
+		ds := &days[i]
+		for j, f := range []solver.LogFunc{ds.a, ds.b} {
+			dp := aoc.DatePart{Date: aoc.Date{Year: year, Day: ds.day}, Part: aoc.Part1 + aoc.Part(j)}
+			declared := found.Variants(dp)
+			if f == nil || len(declared) == 0 {
+				continue
+			}
+			p, err := src.plugin(ds.day)
+			if err != nil {
+				return err
+			}
+			if ds.variants[j], err = solver.VariantsFromPlugin(p, declared); err != nil {
+				return err
+			}
+		}
 	}
-	return f.day, a, b, nil
+	return nil
 }
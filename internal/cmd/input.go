@@ -1,21 +1,31 @@
 package cmd
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/aod/elver/aoc"
-	"github.com/aod/elver/config"
 	"github.com/aod/elver/internal/util"
 )
 
-func getInput(d aoc.Date, sessionID string) ([]byte, error) {
-	inputCacheDir, err := createCacheDir(d.Year)
-	if err != nil {
+// getInput returns the input of d from set, downloading it with sessionID
+// into set when it is not there yet.
+func getInput(set inputSet, d aoc.Date, sessionID string) ([]byte, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	if now := aoc.Clock(); !d.Unlocked(now) {
+		return nil, fmt.Errorf("the puzzle of %s day %s unlocks in %s",
+			d.Year, d.Day, d.UnlockTime().Sub(now).Round(time.Second))
+	}
+
+	inputFile := set.inputFile(d)
+	if err := os.MkdirAll(filepath.Dir(inputFile), 0744); err != nil {
 		return nil, err
 	}
-	inputFile := filepath.Join(inputCacheDir, d.Day.String()+".txt")
 
 	if _, err := os.Stat(inputFile); err != nil && os.IsNotExist(err) {
 		req, err := aoc.CreateInputReq(d, sessionID)
@@ -37,16 +47,3 @@ func getInput(d aoc.Date, sessionID string) ([]byte, error) {
 
 	return ioutil.ReadFile(inputFile)
 }
-
-func createCacheDir(year aoc.Year) (string, error) {
-	cacheDir, err := config.CacheDir()
-	if err != nil {
-		return "", err
-	}
-
-	inputFileDir := filepath.Join(cacheDir, "aoc-inputs", year.String())
-	if err := os.MkdirAll(inputFileDir, 0744); err != nil {
-		return "", err
-	}
-	return inputFileDir, nil
-}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unsafe"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+	"github.com/aod/elver/internal/solver"
+)
+
+// inputSet is a named directory of inputs laid out like the input cache:
+// <dir>/<year>/<day>.txt. Known correct answers live next to the inputs in
+// <dir>/<year>/<day><part>.answer.
+type inputSet struct {
+	name string
+	dir  string
+}
+
+func (s inputSet) inputFile(d aoc.Date) string {
+	return filepath.Join(s.dir, d.Year.String(), d.Day.String()+".txt")
+}
+
+func (s inputSet) answerFile(dp aoc.DatePart) string {
+	return filepath.Join(s.dir, dp.Year.String(), dp.Day.String()+dp.Part.String()+".answer")
+}
+
+// hasInput reports whether the set contains the input of d.
+func (s inputSet) hasInput(d aoc.Date) bool {
+	_, err := os.Stat(s.inputFile(d))
+	return err == nil
+}
+
+// input returns the input of d or false if the set does not contain it.
+func (s inputSet) input(d aoc.Date) (string, bool) {
+	b, err := ioutil.ReadFile(s.inputFile(d))
+	if err != nil {
+		return "", false
+	}
+	return *(*string)(unsafe.Pointer(&b)), true
+}
+
+// answer returns the known correct answer of dp or false if there is none.
+func (s inputSet) answer(dp aoc.DatePart) (string, bool) {
+	b, err := ioutil.ReadFile(s.answerFile(dp))
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(b)), true
+}
+
+// saveAnswer stores answer as the known correct answer of dp.
+func (s inputSet) saveAnswer(dp aoc.DatePart, answer string) error {
+	path := s.answerFile(dp)
+	if err := os.MkdirAll(filepath.Dir(path), 0744); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(answer+"\n"), 0644)
+}
+
+// dirInputSets returns the sub directories of dir as input sets, named after
+// the directory. A dir laid out like the input cache, with a directory per
+// year, is a single input set named after dir itself.
+func dirInputSets(dir string) ([]inputSet, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var sets []inputSet
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		if y, err := strconv.Atoi(info.Name()); err == nil && aoc.Year(y) >= aoc.FirstYear {
+			abs, err := filepath.Abs(dir)
+			if err != nil {
+				return nil, err
+			}
+			return []inputSet{{name: filepath.Base(abs), dir: dir}}, nil
+		}
+		sets = append(sets, inputSet{name: info.Name(), dir: filepath.Join(dir, info.Name())})
+	}
+	return sets, nil
+}
+
+// defaultInputSet returns the input cache of the current session.
+func defaultInputSet() (inputSet, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return inputSet{}, err
+	}
+	return inputSet{name: "default", dir: filepath.Join(cacheDir, "aoc-inputs")}, nil
+}
+
+// accountInputSet returns the input cache of another account which is stored
+// in the accounts directory of the input cache.
+func accountInputSet(name string) (inputSet, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return inputSet{}, fmt.Errorf("invalid account name %q", name)
+	}
+	def, err := defaultInputSet()
+	if err != nil {
+		return inputSet{}, err
+	}
+	return inputSet{name: name, dir: filepath.Join(def.dir, "accounts", name)}, nil
+}
+
+// cachedInputSets returns the input cache of the current session followed by
+// the input caches of other accounts which are stored in the accounts
+// directory of the input cache.
+func cachedInputSets() ([]inputSet, error) {
+	def, err := defaultInputSet()
+	if err != nil {
+		return nil, err
+	}
+	inputsDir := def.dir
+
+	sets := []inputSet{def}
+	infos, err := ioutil.ReadDir(filepath.Join(inputsDir, "accounts"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, info := range infos {
+		if info.IsDir() {
+			sets = append(sets, inputSet{
+				name: info.Name(),
+				dir:  filepath.Join(inputsDir, "accounts", info.Name()),
+			})
+		}
+	}
+	return sets, nil
+}
+
+// runInputSets runs the solvers of date against the input of every set which
+// contains it and prints the answers per input side by side. An error is
+// returned when an answer differs from the known correct answer.
+func runInputSets(w io.Writer, opts options, sets []inputSet, date aoc.Date, funcs [2]solver.LogFunc) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Day %s\t%s\t%s\n", date.Day, aoc.Part1, aoc.Part2)
+
+	found := false
+	var checked, wrong int
+	for _, set := range sets {
+		input, ok := set.input(date)
+		if !ok {
+			continue
+		}
+		found = true
+
+		fmt.Fprint(tw, set.name)
+		for i, f := range funcs {
+			fmt.Fprint(tw, "\t")
+			if f == nil {
+				fmt.Fprint(tw, "-")
+				continue
+			}
+			s := opts.solver(aoc.DatePart{Date: date, Part: aoc.Part1 + aoc.Part(i)}, f)
+			r := s.Result(input, opts.resultKind())
+			want, known := set.answer(s.DatePart)
+			if known && r.Err == nil {
+				checked++
+				if r.Text() != want {
+					wrong++
+				}
+			}
+			fmt.Fprint(tw, inputSetCell(r, want, known))
+		}
+		fmt.Fprintln(tw)
+	}
+	if !found {
+		return fmt.Errorf("no inputs found for %s day %s", date.Year, date.Day)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if wrong > 0 {
+		return fmt.Errorf("%s day %s: %d of %d checked answers are wrong", date.Year, date.Day, wrong, checked)
+	}
+	return nil
+}
+
+func inputSetCell(r solver.Result, want string, known bool) string {
+	var timing string
+	switch r.Attr.ResultKind {
+	case solver.BenchmarkResult:
+		timing = fmt.Sprintf("%d ns/op", r.Attr.B.NsPerOp())
+	case solver.TimeResult:
+		timing = r.Attr.Duration().String()
+	}
+
+	if r.Err != nil {
+		return fmt.Sprintf("[ERROR] %s (%s)", oneLine(r.Err.Error()), timing)
+	}
+	got := r.Text()
+	cell := fmt.Sprintf("%s (%s)", oneLine(got), timing)
+	switch {
+	case !known:
+	case got == want:
+		cell += " ok"
+	default:
+		cell += " WRONG, want " + oneLine(want)
+	}
+	return cell
+}
+
+// oneLine makes multi-line answers fit inside a single table cell.
+func oneLine(s string) string {
+	if !strings.Contains(s, "\n") {
+		return s
+	}
+	return strconv.Quote(s)
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+	"github.com/aod/elver/internal/solver"
+)
+
+// writeFiles writes files, mapping paths relative to dir to their contents.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func setNames(sets []inputSet) []string {
+	var names []string
+	for _, s := range sets {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+func TestDirInputSets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{
+		"alice/2020/5.txt": "abc",
+		"bob/2020/5.txt":   "abcd",
+		"README.md":        "inputs",
+	})
+
+	sets, err := dirInputSets(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := setNames(sets), []string{"alice", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dirInputSets = %v, want %v", got, want)
+	}
+
+	if _, err := dirInputSets(filepath.Join(dir, "missing")); err == nil {
+		t.Error("dirInputSets of a missing directory did not fail")
+	}
+
+	// A directory laid out like the input cache is a single set.
+	cache := filepath.Join(dir, "aoc-inputs")
+	writeFiles(t, cache, map[string]string{
+		"2020/5.txt":              "abc",
+		"2021/1.txt":              "abcd",
+		"accounts/bob/2020/5.txt": "abcde",
+	})
+	sets, err = dirInputSets(cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sets) != 1 || sets[0].name != "aoc-inputs" || sets[0].dir != cache {
+		t.Fatalf("dirInputSets of the cache layout = %+v, want the single set aoc-inputs", sets)
+	}
+	if input, ok := sets[0].input(aoc.Date{Year: 2020, Day: 5}); !ok || input != "abc" {
+		t.Errorf("input of 2020/5 = %q, %t, want abc", input, ok)
+	}
+}
+
+func TestCachedInputSets(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	defer setenv("XDG_CACHE_HOME", tmp)()
+	config.SetAppName("elver")
+
+	sets, err := cachedInputSets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := setNames(sets), []string{"default"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cachedInputSets without accounts = %v, want %v", got, want)
+	}
+
+	bob, err := accountInputSet("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFiles(t, bob.dir, map[string]string{"2020/5.txt": "abcd"})
+
+	if sets, err = cachedInputSets(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := setNames(sets), []string{"default", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cachedInputSets = %v, want %v", got, want)
+	}
+	if in, ok := sets[1].input(aoc.Date{Year: 2020, Day: 5}); !ok || in != "abcd" {
+		t.Errorf("input of bob = %q, %t, want abcd", in, ok)
+	}
+
+	for _, name := range []string{"", ".", "..", "a/b"} {
+		if _, err := accountInputSet(name); err == nil {
+			t.Errorf("accountInputSet(%q) did not fail", name)
+		}
+	}
+}
+
+func TestInputSetCell(t *testing.T) {
+	d := time.Millisecond
+	tests := []struct {
+		r     solver.Result
+		want  string
+		known bool
+		cell  string
+	}{
+		{solver.Result{Answer: 42}, "", false, "42 (1ms)"},
+		{solver.Result{Answer: 42}, "42", true, "42 (1ms) ok"},
+		{solver.Result{Answer: 40}, "42", true, "40 (1ms) WRONG, want 42"},
+		{solver.Result{Answer: "a\nb"}, "", false, `"a\nb" (1ms)`},
+		{solver.Result{Err: errors.New("failed")}, "42", true, "[ERROR] failed (1ms)"},
+	}
+	for _, tt := range tests {
+		tt.r.Attr = solver.ResultAttribute{ResultKind: solver.TimeResult, T: &d}
+		if got := inputSetCell(tt.r, tt.want, tt.known); got != tt.cell {
+			t.Errorf("inputSetCell(%v, %q, %t) = %q, want %q", tt.r.Answer, tt.want, tt.known, got, tt.cell)
+		}
+	}
+}
+
+func TestRunInputSets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeFiles(t, dir, map[string]string{
+		"alice/2020/5.txt":     "abc",
+		"alice/2020/5A.answer": "3\n",
+		"bob/2020/5.txt":       "abcd",
+		"bob/2020/5A.answer":   "5",
+		"carol/2019/5.txt":     "abcde",
+	})
+	sets, err := dirInputSets(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	length := solver.Adapt(func(in string) (interface{}, error) { return len(in), nil })
+	date := aoc.Date{Year: 2020, Day: 5}
+	var out bytes.Buffer
+	err = runInputSets(&out, options{runs: 1}, sets, date, [2]solver.LogFunc{length, nil})
+	if want := "2020 day 5: 1 of 2 checked answers are wrong"; err == nil || err.Error() != want {
+		t.Errorf("runInputSets() = %v, want %q", err, want)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want a header and alice and bob:\n%s", len(lines), out.String())
+	}
+	for i, want := range []string{"alice  3 (", "bob    4 ("} {
+		if !strings.HasPrefix(lines[i+1], want) {
+			t.Errorf("line %d = %q, want prefix %q", i+1, lines[i+1], want)
+		}
+	}
+	if !strings.Contains(lines[1], ") ok") || !strings.Contains(lines[2], "WRONG, want 5") {
+		t.Errorf("answers not checked:\n%s", out.String())
+	}
+	if !strings.HasSuffix(lines[1], "-") {
+		t.Errorf("part B without a solver = %q, want -", lines[1])
+	}
+
+	if err := runInputSets(&out, options{runs: 1}, sets, aoc.Date{Year: 2020, Day: 6}, [2]solver.LogFunc{length, nil}); err == nil {
+		t.Error("runInputSets without inputs did not fail")
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/internal/solver"
+)
+
+// layout is how the solvers of a year are organized in its directory.
+type layout string
+
+const (
+	// layoutAuto uses the day layout for years with day directories and the
+	// year layout otherwise.
+	layoutAuto layout = "auto"
+	// layoutYear is a single main package per year, e.g. 2020/05.go.
+	layoutYear layout = "year"
+	// layoutDay is a main package per day, e.g. 2020/day05/ or 2020/05/.
+	layoutDay layout = "day"
+)
+
+func parseLayout(s string) (layout, error) {
+	switch l := layout(s); l {
+	case layoutAuto, layoutYear, layoutDay:
+		return l, nil
+	}
+	return "", fmt.Errorf("invalid -layout %q, must be auto, year or day", s)
+}
+
+// dayDirRe matches the directory of a day, e.g. day05, day5, 05 or 5.
+var dayDirRe = regexp.MustCompile(`(?i)^(?:day_?)?0*([1-9][0-9]?)$`)
+
+// findDayDirs returns the directories of the days of year in yPath which
+// contain Go files.
+func findDayDirs(year aoc.Year, yPath string) (map[aoc.Day]string, error) {
+	infos, err := ioutil.ReadDir(yPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := make(map[aoc.Day]string)
+	for _, info := range infos {
+		m := dayDirRe.FindStringSubmatch(info.Name())
+		if !info.IsDir() || m == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		day := aoc.Day(n)
+		path := filepath.Join(yPath, info.Name())
+		if !aoc.Event(year).Has(day) || !hasGoFiles(path) {
+			continue
+		}
+		if other, ok := dirs[day]; ok {
+			return nil, fmt.Errorf("%s day %s has multiple directories: %s and %s", year, day, other, path)
+		}
+		dirs[day] = path
+	}
+	return dirs, nil
+}
+
+func hasGoFiles(dir string) bool {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, f := range files {
+		if !strings.HasSuffix(f, "_test.go") {
+			return true
+		}
+	}
+	return false
+}
+
+// withLayout returns dir with its layout resolved from l.
+func (dir yearDir) withLayout(l layout) (yearDir, error) {
+	days, err := findDayDirs(dir.year, dir.path)
+	if err != nil {
+		return dir, err
+	}
+	dir.layout = layoutYear
+	if l == layoutDay || l == layoutAuto && len(days) > 0 {
+		dir.layout, dir.days = layoutDay, days
+	}
+	return dir, nil
+}
+
+// withLayouts resolves the layout of all dirs in place.
+func withLayouts(dirs []yearDir, l layout) error {
+	for i, dir := range dirs {
+		var err error
+		if dirs[i], err = dir.withLayout(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discover finds the solvers declared in dir without building it.
+func (dir yearDir) discover() (solver.Discovery, error) {
+	if dir.layout != layoutDay {
+		return solver.Discover(dir.path, dir.year)
+	}
+
+	var found solver.Discovery
+	for _, day := range aoc.Event(dir.year).Days() {
+		path, ok := dir.days[day]
+		if !ok {
+			continue
+		}
+		d, err := solver.DiscoverDay(path, aoc.Date{Year: dir.year, Day: day})
+		if err != nil {
+			return found, err
+		}
+		found.Solvers = append(found.Solvers, d.Solvers...)
+		found.Warnings = append(found.Warnings, d.Warnings...)
+	}
+	return found, nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aod/elver/aoc"
+)
+
+func TestWithLayout(t *testing.T) {
+	yPath, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(yPath)
+
+	files := []string{"day05/main.go", "12/main.go", "day3/input.txt", "day30/main.go", "notes/main.go"}
+	for _, name := range files {
+		path := filepath.Join(yPath, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte("package main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := yearDir{year: 2020, path: yPath}
+	got, err := dir.withLayout(layoutAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[aoc.Day]string{5: filepath.Join(yPath, "day05"), 12: filepath.Join(yPath, "12")}
+	if got.layout != layoutDay || !reflect.DeepEqual(got.days, want) {
+		t.Errorf("withLayout(auto) = %s %v, want %s %v", got.layout, got.days, layoutDay, want)
+	}
+
+	if got, err = dir.withLayout(layoutYear); err != nil || got.layout != layoutYear {
+		t.Errorf("withLayout(year) = %s, %v, want %s", got.layout, err, layoutYear)
+	}
+
+	if err := os.MkdirAll(filepath.Join(yPath, "5"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(yPath, "5", "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dir.withLayout(layoutAuto); err == nil {
+		t.Error("withLayout with two directories for day 5 did not fail")
+	}
+}
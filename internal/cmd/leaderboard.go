@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+	"github.com/aod/elver/flags"
+	"github.com/aod/elver/internal/leaderboard"
+)
+
+// leaderboardMaxAge is how long a fetched leaderboard is cached. The website
+// asks to not request a private leaderboard more often than every 15 minutes.
+const leaderboardMaxAge = 15 * time.Minute
+
+func leaderboardCmd(args []string) error {
+	if len(args) > 0 && args[0] == "stats" {
+		return leaderboardStatsCmd(args[1:])
+	}
+
+	fs := flag.NewFlagSet("leaderboard", flag.ExitOnError)
+	year := &flags.IntRange{Value: int(aoc.LastYear()), Min: int(aoc.FirstYear), Max: int(aoc.LastYear())}
+	fs.Var(year, "y", "the `year` of the leaderboard")
+
+	pos, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return errors.New("usage: elver leaderboard [-y year] <id>")
+	}
+
+	l, err := getLeaderboard(aoc.Year(year.Value), pos[0])
+	if err != nil {
+		return err
+	}
+	return printLeaderboard(os.Stdout, aoc.Year(year.Value), pos[0], l)
+}
+
+func leaderboardStatsCmd(args []string) error {
+	fs := flag.NewFlagSet("leaderboard stats", flag.ExitOnError)
+	year := &flags.IntRange{Value: int(aoc.LastYear()), Min: int(aoc.FirstYear), Max: int(aoc.LastYear())}
+	fs.Var(year, "y", "the `year` of the leaderboard")
+	scoring := fs.String("scoring", "local", "the `rule` to recompute scores with: local, global, stars or podium")
+	format := fs.String("format", "text", "the output `format`: text, csv or json")
+	table := fs.String("table", leaderboard.MembersTable, "the `table` to export as csv: members, days or history")
+	out := fs.String("o", "", "write the output to `file` instead of stdout")
+
+	pos, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return errors.New("usage: elver leaderboard stats [-y year] [-scoring rule] [-format format] [-table table] [-o file] <id>")
+	}
+
+	l, err := getLeaderboard(aoc.Year(year.Value), pos[0])
+	if err != nil {
+		return err
+	}
+	stats, err := leaderboard.Compute(l, *scoring)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch *format {
+	case "text":
+		return leaderboard.WriteText(w, stats)
+	case "csv":
+		return leaderboard.WriteCSV(w, stats, *table)
+	case "json":
+		return leaderboard.WriteJSON(w, stats)
+	}
+	return fmt.Errorf("unknown format %q, expected one of: text, csv, json", *format)
+}
+
+func getLeaderboard(year aoc.Year, id string) (*aoc.Leaderboard, error) {
+	if _, err := strconv.Atoi(id); err != nil {
+		return nil, fmt.Errorf("invalid leaderboard id %q", id)
+	}
+
+	sessionID, err := readSession()
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(cacheDir, "leaderboards", year.String(), id+".json")
+	b, err := fetchCached(path, leaderboardMaxAge, func() (*http.Request, error) {
+		return aoc.CreateLeaderboardReq(year, id, sessionID)
+	}, func(b []byte) error {
+		_, err := aoc.ParseLeaderboard(b)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aoc.ParseLeaderboard(b)
+}
+
+func printLeaderboard(w io.Writer, year aoc.Year, id string, l *aoc.Leaderboard) error {
+	fmt.Fprintln(w, "AOC", year, "private leaderboard", id)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tSCORE\tSTARS\tNAME")
+	members := l.Ranked()
+	for i, m := range members {
+		fmt.Fprintf(tw, "%d)\t%d\t%d\t%s\n", i+1, m.LocalScore, m.Stars, m.DisplayName())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	event := aoc.Event(year)
+	for _, day := range event.Days() {
+		solves := daySolves(members, day, event.Unlock(day))
+		if len(solves) == 0 {
+			continue
+		}
+
+		fmt.Fprintln(w)
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintf(tw, "Day %s\t%s\t%s\tDELTA\n", day, aoc.Part1, aoc.Part2)
+		for _, s := range solves {
+			fmt.Fprintf(tw, "%s\t%s\t", s.member.DisplayName(), leaderboard.Duration(s.a))
+			if s.b == nil {
+				fmt.Fprintln(tw, "-\t-")
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t+%s\n", leaderboard.Duration(*s.b), leaderboard.Duration(*s.b-s.a))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// solve holds the times since unlock at which a member earned the stars of a
+// day. b is nil when only the first star was earned.
+type solve struct {
+	member aoc.Member
+	a      time.Duration
+	b      *time.Duration
+}
+
+// daySolves returns the solves of day ordered by who finished the day first.
+func daySolves(members []aoc.Member, day aoc.Day, unlock time.Time) []solve {
+	var solves []solve
+	for _, m := range members {
+		a, ok := m.Star(day, aoc.Part1)
+		if !ok {
+			continue
+		}
+		s := solve{member: m, a: a.Sub(unlock)}
+		if b, ok := m.Star(day, aoc.Part2); ok {
+			d := b.Sub(unlock)
+			s.b = &d
+		}
+		solves = append(solves, s)
+	}
+
+	sort.SliceStable(solves, func(i, j int) bool {
+		a, b := solves[i], solves[j]
+		if (a.b == nil) != (b.b == nil) {
+			return a.b != nil
+		}
+		if a.b != nil && *a.b != *b.b {
+			return *a.b < *b.b
+		}
+		return a.a < b.a
+	})
+	return solves
+}
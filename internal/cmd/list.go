@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/flags"
+	"github.com/aod/elver/internal/solver"
+)
+
+func listCmd(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	year := &flags.IntSet{Min: int(aoc.FirstYear), Max: int(aoc.LastYear())}
+	fs.Var(year, "y", "the `years` to list, defaults to all years")
+	layoutFlag := fs.String("layout", "auto", "the `layout` of the year directories: year, day or auto")
+	fs.Parse(args)
+
+	layout, err := parseLayout(*layoutFlag)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	proj, err := findProject(cwd)
+	if err != nil {
+		return err
+	}
+
+	var finder yearDirFinder = yearSetDirFinder{years: aoc.Years()}
+	switch {
+	case year.Latest:
+		finder = latestYearDirFinder{}
+	case !year.Empty():
+		set := yearSetDirFinder{}
+		for _, y := range year.Values() {
+			set.years = append(set.years, aoc.Year(y))
+		}
+		finder = set
+	}
+	dirs, err := finder.findYearDirs(proj.root)
+	if err != nil {
+		return err
+	}
+	if err := withLayouts(dirs, layout); err != nil {
+		return err
+	}
+
+	for i, dir := range dirs {
+		if i > 0 {
+			fmt.Println()
+		}
+		d, err := dir.discover()
+		if err != nil {
+			return err
+		}
+		if err := printDiscovery(os.Stdout, cwd, dir.year, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printDiscovery prints the solvers declared in the directory of a year and
+// the warnings about functions which look like a solver.
+func printDiscovery(w io.Writer, cwd string, year aoc.Year, d solver.Discovery) error {
+	fmt.Fprintf(w, "AOC %s (%d solvers)\n", year, len(d.Solvers))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, s := range d.Solvers {
+		fmt.Fprintf(tw, "%s\t%s\n", s.Name, relPos(cwd, s.Pos))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	printWarnings(w, cwd, d.Warnings)
+	return nil
+}
+
+// printWarnings prints warnings with their position relative to cwd.
+func printWarnings(w io.Writer, cwd string, warnings []solver.Warning) {
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "warning: %s: %s\n", relPos(cwd, warning.Pos), warning.Msg)
+	}
+}
+
+// relPos formats pos with its file relative to cwd when possible.
+func relPos(cwd string, pos token.Position) string {
+	if rel, err := filepath.Rel(cwd, pos.Filename); err == nil {
+		pos.Filename = rel
+	}
+	return pos.String()
+}
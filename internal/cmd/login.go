@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"unsafe"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+	"github.com/aod/elver/internal/term"
+	"github.com/aod/elver/internal/util"
+)
+
+// The environment variable and config file where the session is stored.
+const (
+	sessionEnv  = "AOC_SESSION"
+	sessionFile = "aoc_session"
+)
+
+func readSession() (string, error) {
+	if err := config.Restrict(sessionFile); err != nil {
+		return "", err
+	}
+	sessReader, err := config.EnvOrContents(sessionEnv, sessionFile)
+	if err != nil {
+		return "", err
+	}
+	b, err := ioutil.ReadAll(sessReader)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(*(*string)(unsafe.Pointer(&b))), nil
+}
+
+func fetchUser(sessionID string) (string, error) {
+	req, err := aoc.CreateUserReq(sessionID)
+	if err != nil {
+		return "", err
+	}
+	body, err := util.Fetch(req)
+	if err != nil {
+		return "", err
+	}
+	return aoc.ParseUser(body)
+}
+
+func loginCmd(args []string) error {
+	fs := flag.NewFlagSet("login", flag.ExitOnError)
+	fs.Parse(args)
+
+	fmt.Fprint(os.Stderr, "Advent of Code session token: ")
+	token, err := term.ReadPassword(os.Stdin)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return err
+	}
+	return login(os.Stdout, token)
+}
+
+// login validates token and stores it in the session file.
+func login(w io.Writer, token string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return errors.New("no session token given")
+	}
+
+	user, err := fetchUser(token)
+	if err != nil {
+		return fmt.Errorf("invalid session token: %w", err)
+	}
+	if err := config.WriteContents(sessionFile, []byte(token)); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "Logged in as", user)
+	if _, ok := os.LookupEnv(sessionEnv); ok {
+		fmt.Fprintf(w, "Note: the %s environment variable takes precedence over the stored session\n", sessionEnv)
+	}
+	return nil
+}
+
+func logoutCmd(args []string) error {
+	fs := flag.NewFlagSet("logout", flag.ExitOnError)
+	fs.Parse(args)
+	return logout(os.Stdout)
+}
+
+// logout removes the session file.
+func logout(w io.Writer) error {
+	if err := config.RemoveContents(sessionFile); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "Logged out")
+	if _, ok := os.LookupEnv(sessionEnv); ok {
+		fmt.Fprintf(w, "Note: the %s environment variable is still set\n", sessionEnv)
+	}
+	return nil
+}
+
+func whoamiCmd(args []string) error {
+	fs := flag.NewFlagSet("whoami", flag.ExitOnError)
+	fs.Parse(args)
+	return whoami(os.Stdout)
+}
+
+// whoami prints the user the current session belongs to.
+func whoami(w io.Writer) error {
+	sessionID, err := readSession()
+	if err != nil {
+		return err
+	}
+	user, err := fetchUser(sessionID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, user)
+	return nil
+}
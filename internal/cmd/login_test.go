@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+)
+
+// standIn replaces the Advent of Code website and the user's config dir for
+// the duration of a test. Only the session "valid" is logged in as alice.
+func standIn(t *testing.T) (configDir string, cleanup func()) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie("session")
+		if err != nil || c.Value != "valid" {
+			w.Write([]byte(`<a href="/2020/auth/login">[Log In]</a>`))
+			return
+		}
+		w.Write([]byte(`<div class="user">alice <span class="star-count">42*</span></div>`))
+	}))
+
+	tmp, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restoreHome := setenv("HOME", tmp)
+	restoreXDG := setenv("XDG_CONFIG_HOME", tmp)
+	restoreSession := setenv(sessionEnv, "")
+	os.Unsetenv(sessionEnv)
+
+	baseURL := aoc.BaseURL
+	aoc.BaseURL = srv.URL
+	config.SetAppName("elver")
+	dir, err := config.Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func() {
+		aoc.BaseURL = baseURL
+		restoreHome()
+		restoreXDG()
+		restoreSession()
+		os.RemoveAll(tmp)
+		srv.Close()
+	}
+}
+
+// setenv sets the environment variable key and returns a function which
+// restores its previous state.
+func setenv(key, value string) (restore func()) {
+	prev, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	return func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestLogin(t *testing.T) {
+	dir, cleanup := standIn(t)
+	defer cleanup()
+
+	var out bytes.Buffer
+	if err := login(&out, "valid\n"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "alice") {
+		t.Errorf("expected user alice in output, got %q", out.String())
+	}
+
+	path := filepath.Join(dir, sessionFile)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected permissions 0600, got %o", perm)
+	}
+	b, _ := ioutil.ReadFile(path)
+	if string(b) != "valid" {
+		t.Errorf("expected stored session %q, got %q", "valid", b)
+	}
+}
+
+func TestLoginInvalid(t *testing.T) {
+	dir, cleanup := standIn(t)
+	defer cleanup()
+
+	if err := login(ioutil.Discard, "invalid"); err == nil {
+		t.Error("expected an error for an invalid session")
+	}
+	if _, err := os.Stat(filepath.Join(dir, sessionFile)); !os.IsNotExist(err) {
+		t.Error("expected no session file to be written")
+	}
+}
+
+func TestLoginTightensPermissions(t *testing.T) {
+	dir, cleanup := standIn(t)
+	defer cleanup()
+
+	path := filepath.Join(dir, sessionFile)
+	os.MkdirAll(dir, 0755)
+	if err := ioutil.WriteFile(path, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The link keeps the old file around to check the session was never
+	// written to it.
+	link := filepath.Join(dir, "old-session")
+	if err := os.Link(path, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := login(ioutil.Discard, "valid"); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadFile(link); string(b) != "old" {
+		t.Errorf("expected the session to not be written to the old file, got %q", b)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected permissions 0600, got %o", perm)
+	}
+}
+
+func TestWhoamiLogout(t *testing.T) {
+	dir, cleanup := standIn(t)
+	defer cleanup()
+
+	os.MkdirAll(dir, 0755)
+	path := filepath.Join(dir, sessionFile)
+	if err := ioutil.WriteFile(path, []byte("valid\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := whoami(&out); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(out.String()); got != "alice" {
+		t.Errorf("expected alice, got %q", got)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("expected whoami to tighten permissions, got %o", info.Mode().Perm())
+	}
+
+	if err := logout(ioutil.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("expected session file to be removed")
+	}
+	if err := whoami(ioutil.Discard); err == nil {
+		t.Error("expected an error after logging out")
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+	"github.com/aod/elver/internal/solver"
+)
+
+// outputMode decides what happens with what solvers write to the standard
+// output and error.
+type outputMode string
+
+const (
+	// outputShow captures the output and shows it after the answer.
+	outputShow outputMode = "show"
+	// outputHide captures the output and discards it.
+	outputHide outputMode = "hide"
+	// outputSave captures the output and saves it to a file per day.
+	outputSave outputMode = "save"
+	// outputOff does not capture the output which is written as it happens.
+	outputOff outputMode = "off"
+)
+
+// parseOutputMode parses the -output flag. Without a mode the output is shown,
+// unless solvers run in parallel since capturing would run them one at a
+// time.
+func parseOutputMode(s string, jobs int) (outputMode, error) {
+	switch m := outputMode(s); m {
+	case "":
+		if jobs > 1 {
+			return outputOff, nil
+		}
+		return outputShow, nil
+	case outputShow, outputHide, outputSave, outputOff:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid -output %q, must be show, hide, save or off", s)
+}
+
+func outputFile(d aoc.Date) (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "output", d.Year.String(), d.Day.String()+".txt"), nil
+}
+
+// outputs collects the captured output of the parts of a day.
+type outputs map[aoc.Date]*strings.Builder
+
+// handle shows or saves the captured output of r as selected by mode.
+func (o outputs) handle(w io.Writer, mode outputMode, r solver.Result) error {
+	if r.OutputErr != nil {
+		fmt.Fprintln(w, "> output not captured:", r.OutputErr)
+	}
+	if r.Output == "" {
+		return nil
+	}
+	switch mode {
+	case outputShow:
+		fmt.Fprintln(w, "v output:")
+		for _, line := range strings.Split(strings.TrimSuffix(r.Output, "\n"), "\n") {
+			fmt.Fprintln(w, "|", line)
+		}
+	case outputSave:
+		sb, ok := o[r.Date]
+		if !ok {
+			sb = new(strings.Builder)
+			o[r.Date] = sb
+		}
+		fmt.Fprintf(sb, "Day %s %s:\n%s", r.Day, r.Part, r.Output)
+		if !strings.HasSuffix(r.Output, "\n") {
+			sb.WriteByte('\n')
+		}
+
+		path, err := outputFile(r.Date)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0744); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintln(w, "> output saved to", path)
+	}
+	return nil
+}
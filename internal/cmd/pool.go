@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"github.com/aod/elver/internal/solver"
+)
+
+// job is a single solver to run against an input. note is printed after its
+// result.
+type job struct {
+	solver solver.Solver
+	input  string
+	note   string
+	// compare is set on the last variant of a part to compare the results of
+	// all solvers of the part.
+	compare bool
+}
+
+// runJobs runs jobs on the given number of workers and calls done with every
+// result in the order of jobs, as soon as it and all results before it are
+// available. Benchmarks always run serially so they are not influenced by
+// each other.
+func runJobs(jobs []job, k solver.ResultKind, workers int, done func(job, solver.Result) error) error {
+	if workers <= 1 || k == solver.BenchmarkResult {
+		for _, j := range jobs {
+			if err := done(j, j.solver.Result(j.input, k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	results := make([]chan solver.Result, len(jobs))
+	for i := range results {
+		results[i] = make(chan solver.Result, 1)
+	}
+
+	next := make(chan int)
+	quit := make(chan struct{})
+	defer close(quit)
+	go func() {
+		defer close(next)
+		for i := range jobs {
+			select {
+			case next <- i:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range next {
+				results[i] <- jobs[i].solver.Result(jobs[i].input, k)
+			}
+		}()
+	}
+
+	for i, c := range results {
+		if err := done(jobs[i], <-c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/internal/solver"
+)
+
+func TestRunJobsOrder(t *testing.T) {
+	var jobs []job
+	for day := aoc.Day(1); day <= 5; day++ {
+		wait := time.Duration(6-day) * 10 * time.Millisecond
+		jobs = append(jobs, job{
+			solver: solver.Solver{
+				DatePart: aoc.DatePart{Date: aoc.Date{Year: 2020, Day: day}, Part: aoc.Part1},
+				Solver: solver.Adapt(func(string) (interface{}, error) {
+					time.Sleep(wait)
+					return nil, nil
+				}),
+			},
+		})
+	}
+
+	for _, workers := range []int{1, 3, 10} {
+		var got []aoc.Day
+		err := runJobs(jobs, solver.TimeResult, workers, func(j job, r solver.Result) error {
+			got = append(got, r.Day)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, day := range got {
+			if day != aoc.Day(i+1) {
+				t.Fatalf("%d workers: expected results in day order, got %v", workers, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aod/elver/aoc"
+)
+
+// rootMarker is the name of the file which marks the root of a project
+// without year directories yet.
+const rootMarker = ".elver"
+
+// project is the root directory of the year directories and the date inferred
+// from where elver runs in it.
+type project struct {
+	root string
+	// year is set when elver runs inside the directory of a year, day when it
+	// runs inside the directory of a day or a file of a day was edited last.
+	year aoc.Year
+	day  aoc.Day
+}
+
+// dayFileRe matches the file of a day, e.g. 05.go, day5.go or day05_parse.go.
+var dayFileRe = regexp.MustCompile(`(?i)^(?:day_?)?0*([1-9][0-9]?)(?:[^0-9].*)?\.go$`)
+
+// findProject walks up from cwd to the nearest directory which contains the
+// root marker file or a year directory. Without one cwd is the root.
+func findProject(cwd string) (project, error) {
+	for dir := cwd; ; {
+		if isRoot(dir) {
+			return inferDate(dir, cwd)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return project{root: cwd}, nil
+		}
+		dir = parent
+	}
+}
+
+func isRoot(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, rootMarker)); err == nil {
+		return true
+	}
+	_, _, err := aoc.Years().FirstYearDir(dir)
+	return err == nil
+}
+
+// inferDate infers the year and day of project root from cwd inside of it.
+func inferDate(root, cwd string) (project, error) {
+	proj := project{root: root}
+	rel, err := filepath.Rel(root, cwd)
+	if err != nil || rel == "." {
+		return proj, err
+	}
+	elems := strings.Split(rel, string(filepath.Separator))
+
+	y, err := strconv.Atoi(elems[0])
+	if err != nil || aoc.Year(y) < aoc.FirstYear || aoc.Year(y) > aoc.LastYear() {
+		return proj, nil
+	}
+	proj.year = aoc.Year(y)
+
+	if len(elems) > 1 {
+		if m := dayDirRe.FindStringSubmatch(elems[1]); m != nil {
+			n, _ := strconv.Atoi(m[1])
+			proj.day = aoc.Day(n)
+		}
+	} else {
+		proj.day, err = lastEditedDay(cwd)
+		if err != nil {
+			return proj, err
+		}
+	}
+	if !aoc.Event(proj.year).Has(proj.day) {
+		proj.day = 0
+	}
+	return proj, nil
+}
+
+// lastEditedDay returns the day of the most recently modified file of a day
+// in the directory of a year, or 0 without one.
+func lastEditedDay(yPath string) (aoc.Day, error) {
+	infos, err := ioutil.ReadDir(yPath)
+	if err != nil {
+		return 0, err
+	}
+
+	var day aoc.Day
+	var last os.FileInfo
+	for _, info := range infos {
+		m := dayFileRe.FindStringSubmatch(info.Name())
+		if info.IsDir() || m == nil {
+			continue
+		}
+		if last == nil || info.ModTime().After(last.ModTime()) {
+			n, _ := strconv.Atoi(m[1])
+			day, last = aoc.Day(n), info
+		}
+	}
+	return day, nil
+}
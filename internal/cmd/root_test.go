@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aod/elver/aoc"
+)
+
+func TestFindProject(t *testing.T) {
+	root, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	for _, dir := range []string{"2019/day05/input", "2020", "notes"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := map[string]time.Time{
+		"2020/03.go":    time.Now().Add(-time.Hour),
+		"2020/day7.go":  time.Now(),
+		"2020/main.go":  time.Now().Add(time.Hour),
+		"2020/2020.txt": time.Now().Add(time.Hour),
+	}
+	for name, mtime := range files {
+		path := filepath.Join(root, name)
+		if err := ioutil.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		cwd  string
+		year aoc.Year
+		day  aoc.Day
+	}{
+		{"", 0, 0},
+		{"notes", 0, 0},
+		{"2020", 2020, 7},
+		{"2019", 2019, 0},
+		{"2019/day05/input", 2019, 5},
+	}
+	for _, tt := range tests {
+		got, err := findProject(filepath.Join(root, tt.cwd))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := project{root: root, year: tt.year, day: tt.day}
+		if got != want {
+			t.Errorf("findProject(%q) = %+v, want %+v", tt.cwd, got, want)
+		}
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(root, "notes", rootMarker), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	notes := filepath.Join(root, "notes")
+	if got, err := findProject(notes); err != nil || got.root != notes {
+		t.Errorf("findProject with a marker = %+v, %v, want root %s", got, err, notes)
+	}
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+	"github.com/aod/elver/flags"
+)
+
+// calendarMaxAge and puzzleMaxAge are how long fetched calendar and puzzle
+// pages are cached.
+const (
+	calendarMaxAge = 15 * time.Minute
+	puzzleMaxAge   = 15 * time.Minute
+)
+
+// puzzleFetchInterval is the minimum time between fetching two puzzle pages so
+// a calendar full of missing answers does not flood the website.
+var puzzleFetchInterval = time.Second
+
+// statusColumns is the number of days shown per row of the calendar.
+const statusColumns = 5
+
+func statusCmd(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	year := &flags.IntSet{Min: int(aoc.FirstYear), Max: int(aoc.LastYear())}
+	fs.Var(year, "y", "the `years` to show, defaults to all years")
+	layoutFlag := fs.String("layout", "auto", "the `layout` of the year directories: year, day or auto")
+	fs.Parse(args)
+
+	layout, err := parseLayout(*layoutFlag)
+	if err != nil {
+		return err
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	proj, err := findProject(cwd)
+	if err != nil {
+		return err
+	}
+
+	years := aoc.Years()
+	switch {
+	case year.Latest && len(years) > 0:
+		years = years[len(years)-1:]
+	case !year.Empty():
+		years = years[:0]
+		for _, y := range year.Values() {
+			years = append(years, aoc.Year(y))
+		}
+	}
+
+	sessionID, sessErr := readSession()
+	found := false
+	for _, y := range years {
+		yPath, err := y.FindDir(proj.root)
+		if err != nil {
+			continue
+		}
+		dir, err := yearDir{year: y, path: yPath}.withLayout(layout)
+		if err != nil {
+			return err
+		}
+		if found {
+			fmt.Println()
+		}
+		found = true
+
+		var stars map[aoc.Day]int
+		if sessErr == nil {
+			if stars, err = getStars(y, sessionID); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: could not fetch stars: %s\n", y, err)
+			}
+			if err := fetchAnswers(y, stars, sessionID); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: could not fetch answers: %s\n", y, err)
+			}
+		}
+
+		days, err := yearStatus(dir, stars)
+		if err != nil {
+			return err
+		}
+		printStatus(os.Stdout, y, days, stars != nil)
+	}
+	if !found {
+		return fmt.Errorf("no advent year directory found in %s", proj.root)
+	}
+
+	now := aoc.Clock()
+	next := aoc.NextUnlock(now)
+	fmt.Printf("\nNext puzzle: %s day %s unlocks in %s\n",
+		next.Year, next.Day, next.UnlockTime().Sub(now).Round(time.Second))
+	return nil
+}
+
+func getStars(year aoc.Year, sessionID string) (map[aoc.Day]int, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(cacheDir, "calendars", year.String()+".html")
+	b, err := fetchCached(path, calendarMaxAge, func() (*http.Request, error) {
+		return aoc.CreateCalendarReq(year, sessionID)
+	}, func(b []byte) error {
+		_, err := aoc.ParseCalendarStars(b)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aoc.ParseCalendarStars(b)
+}
+
+// fetchAnswers saves the answers of the parts of year which earned a star as
+// known correct answers. They are taken from the puzzle pages which are only
+// fetched for days with answers which are not saved yet. The pages are cached
+// and fetched at most once per puzzleFetchInterval.
+func fetchAnswers(year aoc.Year, stars map[aoc.Day]int, sessionID string) error {
+	set, err := defaultInputSet()
+	if err != nil {
+		return err
+	}
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return err
+	}
+
+	var last time.Time
+	event := aoc.Event(year)
+	for _, day := range event.Days() {
+		date := aoc.Date{Year: year, Day: day}
+		solved := stars[day]
+		if event.SinglePart(day) && solved > 1 {
+			solved = 1
+		}
+		missing := false
+		for i := 0; i < solved; i++ {
+			if _, ok := set.answer(aoc.DatePart{Date: date, Part: aoc.Part1 + aoc.Part(i)}); !ok {
+				missing = true
+			}
+		}
+		if !missing {
+			continue
+		}
+
+		path := filepath.Join(cacheDir, "puzzles", year.String(), day.String()+".html")
+		page, err := fetchCached(path, puzzleMaxAge, func() (*http.Request, error) {
+			if wait := puzzleFetchInterval - time.Since(last); wait > 0 {
+				time.Sleep(wait)
+			}
+			last = time.Now()
+			return aoc.CreatePuzzleReq(date, sessionID)
+		}, func(b []byte) error {
+			if len(aoc.ParsePuzzleAnswers(b)) == 0 {
+				return fmt.Errorf("no answers found on the puzzle page of day %s", day)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for i, answer := range aoc.ParsePuzzleAnswers(page) {
+			if i >= solved {
+				break
+			}
+			if err := set.saveAnswer(aoc.DatePart{Date: date, Part: aoc.Part1 + aoc.Part(i)}, answer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dayStatus holds the progress of a single day.
+type dayStatus struct {
+	day     aoc.Day
+	solvers [2]bool
+	input   bool
+	answers [2]bool
+	timing  time.Duration
+	stars   int
+
+	// singlePart is set for the final day which only has a part A. The day is
+	// complete once part A is solved.
+	singlePart bool
+}
+
+// complete reports whether all puzzles of the day are solved.
+func (ds dayStatus) complete() bool {
+	return ds.stars == 2 || ds.singlePart && ds.stars == 1
+}
+
+// yearStatus gathers the progress of every day of the year of dir. A year or
+// day whose solvers fail to build is shown without solvers.
+func yearStatus(dir yearDir, stars map[aoc.Day]int) ([]dayStatus, error) {
+	year := dir.year
+	src, err := buildSource(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", year, err)
+	}
+	set, err := defaultInputSet()
+	if err != nil {
+		return nil, err
+	}
+	t, err := loadTimings(year)
+	if err != nil {
+		return nil, err
+	}
+
+	var days []dayStatus
+	event := aoc.Event(year)
+	if src != nil {
+		src.prepare(event.Days())
+	}
+	for _, day := range event.Days() {
+		date := aoc.Date{Year: year, Day: day}
+		ds := dayStatus{day: day, stars: stars[day], singlePart: event.SinglePart(day)}
+		if src != nil {
+			ds.solvers = sourceSolvers(src, date)
+		}
+		ds.input = set.hasInput(date)
+		for i, part := range []aoc.Part{aoc.Part1, aoc.Part2} {
+			_, ds.answers[i] = set.answer(aoc.DatePart{Date: date, Part: part})
+			ds.timing += t[day.String()+part.String()]
+		}
+		days = append(days, ds)
+	}
+	return days, nil
+}
+
+func sourceSolvers(src solverSource, d aoc.Date) [2]bool {
+	a, b, err := src.solvers(d)
+	var be *buildError
+	if errors.As(err, &be) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", d.Year, err)
+	}
+	return [2]bool{a != nil, b != nil}
+}
+
+func printStatus(w io.Writer, year aoc.Year, days []dayStatus, withStars bool) {
+	total := 0
+	for _, ds := range days {
+		total += ds.stars
+	}
+	header := fmt.Sprintf("AOC %s", year)
+	if withStars {
+		header += fmt.Sprintf(" (%d/%d stars)", total, 2*len(days))
+	}
+	fmt.Fprintln(w, header)
+
+	const width = 15
+	border := "+" + strings.Repeat(strings.Repeat("-", width)+"+", statusColumns)
+	for i := 0; i < len(days); i += statusColumns {
+		row := days[i:]
+		if len(row) > statusColumns {
+			row = row[:statusColumns]
+		}
+
+		fmt.Fprintln(w, border[:len(row)*(width+1)+1])
+		line1, line2 := "|", "|"
+		for _, ds := range row {
+			stars := strings.Repeat("*", ds.stars)
+			if !withStars {
+				stars = "?"
+			} else if ds.complete() {
+				stars = "**"
+			}
+			timing := ""
+			if ds.timing > 0 {
+				timing = shortDuration(ds.timing)
+			}
+			line1 += fmt.Sprintf(" %2d %-2s %7s |", ds.day, stars, timing)
+			solverB, answerB := mark(ds.solvers[1], "B"), mark(ds.answers[1], "=")
+			if ds.singlePart {
+				solverB, answerB = " ", " "
+			}
+			line2 += fmt.Sprintf(" %s%s %s %s%s      |",
+				mark(ds.solvers[0], "A"), solverB,
+				mark(ds.input, "in"),
+				mark(ds.answers[0], "="), answerB)
+		}
+		fmt.Fprintln(w, line1)
+		fmt.Fprintln(w, line2)
+	}
+	fmt.Fprintln(w, border)
+	fmt.Fprintln(w, "A/B: solver found, in: input cached, ==: answers confirmed, *: stars earned")
+}
+
+// mark returns s if ok or as many dashes otherwise.
+func mark(ok bool, s string) string {
+	if ok {
+		return s
+	}
+	return strings.Repeat("-", len(s))
+}
+
+// shortDuration rounds d to three significant digits.
+func shortDuration(d time.Duration) string {
+	for r := time.Duration(1); r < time.Hour; r *= 10 {
+		if d < 1000*r {
+			return d.Round(r).String()
+		}
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+)
+
+func TestFetchAnswers(t *testing.T) {
+	var fetched []string
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = append(fetched, r.URL.Path)
+		times = append(times, time.Now())
+		w.Write([]byte(`<p>Your puzzle answer was <code>42</code>.</p><p>Your puzzle answer was <code>7</code>.</p>`))
+	}))
+	defer srv.Close()
+	baseURL := aoc.BaseURL
+	aoc.BaseURL = srv.URL
+	defer func() { aoc.BaseURL = baseURL }()
+	defer func(d time.Duration) { puzzleFetchInterval = d }(puzzleFetchInterval)
+	puzzleFetchInterval = 20 * time.Millisecond
+
+	tmp, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	defer setenv("XDG_CACHE_HOME", tmp)()
+	config.SetAppName("elver")
+
+	stars := map[aoc.Day]int{1: 2, 3: 1, 25: 2}
+	for i := 0; i < 2; i++ {
+		if err := fetchAnswers(2020, stars, "session"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want := []string{"/2020/day/1", "/2020/day/3", "/2020/day/25"}; len(fetched) != len(want) {
+		t.Fatalf("fetched %v, want %v only once", fetched, want)
+	}
+	for i := 1; i < len(times); i++ {
+		if d := times[i].Sub(times[i-1]); d < puzzleFetchInterval {
+			t.Errorf("fetched %s %s after the previous page, want at least %s", fetched[i], d, puzzleFetchInterval)
+		}
+	}
+
+	// A missing answer is taken from the cached page.
+	set, err := defaultInputSet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(set.answerFile(aoc.DatePart{Date: aoc.Date{Year: 2020, Day: 1}, Part: aoc.Part2})); err != nil {
+		t.Fatal(err)
+	}
+	if err := fetchAnswers(2020, stars, "session"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fetched) != 3 {
+		t.Errorf("fetched %v, want the cached page to be used", fetched)
+	}
+	tests := []struct {
+		day    aoc.Day
+		part   aoc.Part
+		answer string
+		ok     bool
+	}{
+		{1, aoc.Part1, "42", true},
+		{1, aoc.Part2, "7", true},
+		{3, aoc.Part1, "42", true},
+		{3, aoc.Part2, "", false},
+		{25, aoc.Part1, "42", true},
+		{25, aoc.Part2, "", false},
+	}
+	for _, tt := range tests {
+		dp := aoc.DatePart{Date: aoc.Date{Year: 2020, Day: tt.day}, Part: tt.part}
+		if got, ok := set.answer(dp); got != tt.answer || ok != tt.ok {
+			t.Errorf("answer of %s = %q, %t, want %q, %t", dp, got, ok, tt.answer, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/internal/solver"
+)
+
+// slowestDays is the number of slowest days shown in the summary.
+const slowestDays = 5
+
+// yearSummary holds the results of all solvers which ran for a year. err is
+// set when the year could not be run at all.
+type yearSummary struct {
+	year    aoc.Year
+	results []solver.Result
+	err     error
+}
+
+// failedYears returns an error naming the years which could not be run, or nil
+// when all years ran.
+func failedYears(years []yearSummary) error {
+	var failed []string
+	for _, ys := range years {
+		if ys.err != nil {
+			failed = append(failed, ys.year.String())
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d years failed: %s", len(failed), len(years), strings.Join(failed, ", "))
+}
+
+// dayDuration is the total time it took to solve both parts of a day.
+type dayDuration struct {
+	date aoc.Date
+	d    time.Duration
+}
+
+func printSummary(w io.Writer, years []yearSummary) error {
+	fmt.Fprintln(w, "Summary")
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "YEAR\tSOLVED\tRUNTIME\tSLOWEST DAY")
+
+	var days []dayDuration
+	var solved, total int
+	var runtime time.Duration
+	for _, ys := range years {
+		if ys.err != nil {
+			fmt.Fprintf(tw, "%s\t-\t-\t[ERROR] %s\n", ys.year, oneLine(ys.err.Error()))
+			continue
+		}
+
+		yearDays := make(map[aoc.Day]time.Duration)
+		var yearSolved int
+		var yearRuntime time.Duration
+		for _, r := range ys.results {
+			if r.Err == nil {
+				yearSolved++
+			}
+			d := r.Attr.Duration()
+			yearRuntime += d
+			yearDays[r.Day] += d
+		}
+
+		slowest := dayDuration{}
+		for day, d := range yearDays {
+			dd := dayDuration{aoc.Date{Year: ys.year, Day: day}, d}
+			days = append(days, dd)
+			if d > slowest.d || d == slowest.d && day < slowest.date.Day {
+				slowest = dd
+			}
+		}
+
+		fmt.Fprintf(tw, "%s\t%d/%d\t%s\t", ys.year, yearSolved, len(ys.results), shortDuration(yearRuntime))
+		if slowest.d > 0 {
+			fmt.Fprintf(tw, "%s (%s)", slowest.date.Day, shortDuration(slowest.d))
+		}
+		fmt.Fprintln(tw)
+
+		solved += yearSolved
+		total += len(ys.results)
+		runtime += yearRuntime
+	}
+	fmt.Fprintf(tw, "TOTAL\t%d/%d\t%s\t\n", solved, total, shortDuration(runtime))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(days) == 0 {
+		return nil
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].d != days[j].d {
+			return days[i].d > days[j].d
+		}
+		return days[i].date.Year < days[j].date.Year ||
+			days[i].date.Year == days[j].date.Year && days[i].date.Day < days[j].date.Day
+	})
+	if len(days) > slowestDays {
+		days = days[:slowestDays]
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Slowest days")
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, dd := range days {
+		fmt.Fprintf(tw, "%s\t%s\n", dd.date, shortDuration(dd.d))
+	}
+	return tw.Flush()
+}
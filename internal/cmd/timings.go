@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/config"
+	"github.com/aod/elver/internal/solver"
+)
+
+// timings holds the latest durations of the solvers of a year by day and part,
+// e.g. "5A".
+type timings map[string]time.Duration
+
+func timingsFile(year aoc.Year) (string, error) {
+	cacheDir, err := config.CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "timings", year.String()+".json"), nil
+}
+
+func loadTimings(year aoc.Year) (timings, error) {
+	path, err := timingsFile(year)
+	if err != nil {
+		return nil, err
+	}
+
+	t := make(timings)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return t, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return t, json.Unmarshal(b, &t)
+}
+
+// saveTiming stores the duration of a successful result as the latest timing
+// of its solver.
+func saveTiming(r solver.Result) error {
+	if r.Err != nil {
+		return nil
+	}
+
+	t, err := loadTimings(r.Year)
+	if err != nil {
+		return err
+	}
+	t[r.Day.String()+r.Part.String()] = r.Attr.Duration()
+
+	path, err := timingsFile(r.Year)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0744); err != nil {
+		return err
+	}
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
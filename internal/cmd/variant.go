@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/aod/elver/internal/solver"
+)
+
+// checkVariant makes the result of a variant fail when its answer differs
+// from that of the solver it is a variant of.
+func checkVariant(primary, r solver.Result) solver.Result {
+	if primary.Err != nil || r.Err != nil {
+		return r
+	}
+	if got, want := r.Text(), primary.Text(); got != want {
+		r.Err = fmt.Errorf("the answer %s differs from %s of the primary solver", oneLine(got), oneLine(want))
+	}
+	return r
+}
+
+// printComparison prints the benchmarks of the variants of a part relative to
+// the primary solver which is the first of results.
+func printComparison(w io.Writer, results []solver.Result) error {
+	primary := results[0]
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Day %s %s\tns/op\tB/op\tallocs/op\n", primary.Day, primary.Part)
+	for _, r := range results {
+		name := r.Variant
+		if name == "" {
+			name = "(primary)"
+		}
+		if r.Err != nil {
+			fmt.Fprintf(tw, "%s\t[ERROR] %s\n", name, oneLine(r.Err.Error()))
+			continue
+		}
+		b, base := r.Attr.B, primary.Attr.B
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", name,
+			relative(b.NsPerOp(), base.NsPerOp(), primary.Err == nil),
+			relative(b.AllocedBytesPerOp(), base.AllocedBytesPerOp(), primary.Err == nil),
+			relative(b.AllocsPerOp(), base.AllocsPerOp(), primary.Err == nil))
+	}
+	return tw.Flush()
+}
+
+// relative formats v followed by how many times it is base.
+func relative(v, base int64, ok bool) string {
+	switch {
+	case !ok:
+		return fmt.Sprint(v)
+	case base == 0 && v == 0:
+		return fmt.Sprintf("%d (1.00x)", v)
+	case base == 0:
+		return fmt.Sprintf("%d (-)", v)
+	}
+	return fmt.Sprintf("%d (%.2fx)", v, float64(v)/float64(base))
+}
@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/internal/capture"
+	"github.com/aod/elver/internal/solver"
+	"github.com/aod/elver/internal/term"
+	"github.com/aod/elver/logger"
+	"github.com/aod/elver/viz"
+)
+
+// defaultMaxFrames is the default maximum number of frames recorded.
+const defaultMaxFrames = 10000
+
+func vizCmd(args []string) error {
+	fs := flag.NewFlagSet("viz", flag.ExitOnError)
+	fps := fs.Float64("fps", 10, "the `number` of frames shown per second")
+	maxFrames := fs.Int("max-frames", defaultMaxFrames, "the maximum `number` of frames recorded, 0 for no limit")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: elver viz [flags] date")
+		fmt.Fprintln(fs.Output(), "Plays back the frames emitted by the solvers of a date, e.g. 2020/5 or 2020/5b.")
+		fs.PrintDefaults()
+	}
+	pos, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		fs.Usage()
+		return errors.New("expected a single date")
+	}
+	if *fps <= 0 {
+		return errors.New("-fps must be positive")
+	}
+
+	dp, err := aoc.ParseDatePart(pos[0])
+	if err != nil {
+		d, err := aoc.ParseDate(pos[0])
+		if err != nil {
+			return err
+		}
+		dp = aoc.DatePart{Date: d}
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	proj, err := findProject(cwd)
+	if err != nil {
+		return err
+	}
+	sessionID, err := readSession()
+	if err != nil {
+		return err
+	}
+
+	rec, err := recordFrames(proj.root, sessionID, dp, *maxFrames)
+	if err != nil {
+		return err
+	}
+	if len(rec.Frames) == 0 {
+		return fmt.Errorf("the solvers of %s did not emit any frames", dp.Date)
+	}
+	if rec.Dropped > 0 {
+		fmt.Fprintf(os.Stderr, "%d frames were dropped, use -max-frames to keep more\n", rec.Dropped)
+	}
+
+	p := &player{frames: rec.Frames, fps: *fps}
+	if !term.IsTerminal(os.Stdin) {
+		p.run(os.Stdout, nil)
+		return nil
+	}
+	restore, err := term.MakeRaw(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	keys := make(chan key)
+	go readKeys(os.Stdin, keys)
+	p.run(os.Stdout, keys)
+	return nil
+}
+
+// recordFrames runs the solvers of dp, or of both parts without a part, and
+// records the frames they emit. The frames are captioned with their part.
+func recordFrames(root, sessionID string, dp aoc.DatePart, max int) (*viz.Recording, error) {
+	if err := dp.Date.Validate(); err != nil {
+		return nil, err
+	}
+	if aoc.Event(dp.Year).SinglePart(dp.Day) && dp.Part == aoc.Part2 {
+		return nil, fmt.Errorf("%s: the final day only has a single puzzle", dp.Date)
+	}
+
+	dirs, err := specificYearDirFinder{year: dp.Year}.findYearDirs(root)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := dirs[0].withLayout(layoutAuto)
+	if err != nil {
+		return nil, err
+	}
+	src, err := buildSource(dir)
+	if err != nil {
+		return nil, err
+	}
+	days, err := specificDaySolversFinder{day: dp.Day}.findSolvers(src, dp.Year)
+	if err != nil {
+		return nil, err
+	}
+	funcA, funcB, err := options{part: dp.Part}.selectParts(dp.Date, days[0])
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := defaultInputSet()
+	if err != nil {
+		return nil, err
+	}
+	b, err := getInput(set, dp.Date, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	input := string(b)
+
+	rec := &viz.Recording{Max: max}
+	viz.SetRecorder(rec)
+	defer viz.SetRecorder(nil)
+	for i, f := range []solver.LogFunc{funcA, funcB} {
+		if f == nil {
+			continue
+		}
+		part := aoc.Part1 + aoc.Part(i)
+		start := len(rec.Frames)
+
+		var err error
+		solve := func() { _, err = f(input, logger.Discard) }
+		capture.Discard(solve)
+		if err != nil {
+			return nil, fmt.Errorf("day %s %s: %w", dp.Day, part, err)
+		}
+
+		label := fmt.Sprintf("Day %s %s", dp.Day, part)
+		for j := start; j < len(rec.Frames); j++ {
+			if c := rec.Frames[j].Caption; c != "" {
+				rec.Frames[j].Caption = label + ": " + c
+			} else {
+				rec.Frames[j].Caption = label
+			}
+		}
+	}
+	return rec, nil
+}
+
+// vizOut records the frames emitted by solvers to an image file.
+type vizOut struct {
+	path string
+	opts viz.ImageOptions
+}
+
+// validate checks the file is either a GIF or a PNG.
+func (v vizOut) validate() error {
+	switch strings.ToLower(filepath.Ext(v.path)) {
+	case ".gif", ".png":
+		return nil
+	}
+	return fmt.Errorf("-viz-out %s must be a .gif or .png file", v.path)
+}
+
+// record records the frames emitted while f runs and writes them to the file.
+// A PNG only contains the last frame. Nothing is recorded without a file.
+func (v vizOut) record(f func() error) error {
+	if v.path == "" {
+		return f()
+	}
+
+	rec := &viz.Recording{Max: defaultMaxFrames}
+	viz.SetRecorder(rec)
+	err := f()
+	viz.SetRecorder(nil)
+	if err != nil {
+		return err
+	}
+	if len(rec.Frames) == 0 {
+		return errors.New("the solvers did not emit any frames")
+	}
+
+	file, err := os.Create(v.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	what := fmt.Sprintf("%d frames", len(rec.Frames))
+	if strings.ToLower(filepath.Ext(v.path)) == ".png" {
+		what = fmt.Sprintf("the last of %s", what)
+		err = viz.EncodePNG(file, rec.Frames[len(rec.Frames)-1], v.opts)
+	} else {
+		err = viz.EncodeGIF(file, rec.Frames, v.opts)
+	}
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Recorded %s to %s\n", what, v.path)
+	if rec.Dropped > 0 {
+		fmt.Printf("%d frames were dropped after the first %d\n", rec.Dropped, defaultMaxFrames)
+	}
+	return nil
+}
+
+type key int
+
+const (
+	keyQuit key = iota
+	keyPause
+	keyNext
+	keyPrev
+	keyFirst
+	keyLast
+	keyFaster
+	keySlower
+)
+
+// readKeys reads key presses from a terminal in raw mode until it fails.
+func readKeys(r io.Reader, keys chan<- key) {
+	defer close(keys)
+	b := make([]byte, 1)
+	read := func() (byte, bool) {
+		n, err := r.Read(b)
+		return b[0], n == 1 && err == nil
+	}
+	for {
+		c, ok := read()
+		if !ok {
+			return
+		}
+		switch c {
+		case 'q', 3: // ctrl-c
+			keys <- keyQuit
+		case ' ':
+			keys <- keyPause
+		case 'l', 'n':
+			keys <- keyNext
+		case 'h', 'p':
+			keys <- keyPrev
+		case 'g':
+			keys <- keyFirst
+		case 'G':
+			keys <- keyLast
+		case '+', '=':
+			keys <- keyFaster
+		case '-':
+			keys <- keySlower
+		case 27: // escape sequence of an arrow key
+			if c, ok := read(); !ok || c != '[' {
+				continue
+			}
+			switch c, _ := read(); c {
+			case 'C':
+				keys <- keyNext
+			case 'D':
+				keys <- keyPrev
+			case 'A':
+				keys <- keyFaster
+			case 'B':
+				keys <- keySlower
+			}
+		}
+	}
+}
+
+// player plays back frames in a terminal.
+type player struct {
+	frames []viz.Frame
+	i      int
+	paused bool
+	fps    float64
+}
+
+// handle applies a key press and reports whether to quit.
+func (p *player) handle(k key) bool {
+	last := len(p.frames) - 1
+	switch k {
+	case keyQuit:
+		return true
+	case keyPause:
+		p.paused = !p.paused
+		if !p.paused && p.i == last {
+			p.i = 0
+		}
+	case keyNext:
+		p.paused = true
+		if p.i < last {
+			p.i++
+		}
+	case keyPrev:
+		p.paused = true
+		if p.i > 0 {
+			p.i--
+		}
+	case keyFirst:
+		p.i = 0
+	case keyLast:
+		p.i = last
+	case keyFaster:
+		p.fps *= 2
+	case keySlower:
+		p.fps /= 2
+	}
+	return false
+}
+
+// run plays back the frames until quit. Without keys the frames are played
+// once.
+func (p *player) run(w io.Writer, keys <-chan key) {
+	fmt.Fprint(w, "\x1b[?25l\x1b[2J")
+	defer fmt.Fprint(w, "\x1b[0m\x1b[?25h\r\n")
+
+	for {
+		p.draw(w, keys != nil)
+
+		var tick <-chan time.Time
+		if !p.paused {
+			tick = time.After(time.Duration(float64(time.Second) / p.fps))
+		}
+		select {
+		case k, ok := <-keys:
+			if !ok {
+				keys = nil
+			} else if p.handle(k) {
+				return
+			}
+		case <-tick:
+			switch {
+			case p.i < len(p.frames)-1:
+				p.i++
+			case keys == nil:
+				return
+			default:
+				p.paused = true
+			}
+		}
+	}
+}
+
+func (p *player) draw(w io.Writer, interactive bool) {
+	f := p.frames[p.i]
+	var sb strings.Builder
+	sb.WriteString("\x1b[H")
+	sb.WriteString(renderFrame(f))
+	sb.WriteString(f.Caption + "\x1b[K\r\n")
+	fmt.Fprintf(&sb, "frame %d/%d, %.3g fps", p.i+1, len(p.frames), p.fps)
+	if p.paused {
+		sb.WriteString(", paused")
+	}
+	if interactive {
+		sb.WriteString("  [space] pause  [←/→] step  [+/-] speed  [g/G] first/last  [q] quit")
+	}
+	sb.WriteString("\x1b[K\x1b[J")
+	io.WriteString(w, sb.String())
+}
+
+// renderFrame draws the cells of f using ANSI escape codes for the colors.
+func renderFrame(f viz.Frame) string {
+	var sb strings.Builder
+	for _, row := range f.Cells {
+		fg, bg := viz.Default, viz.Default
+		for _, c := range row {
+			if c.FG != fg || c.BG != bg {
+				fmt.Fprintf(&sb, "\x1b[%d;%dm", ansiColor(c.FG, 39, 30, 90), ansiColor(c.BG, 49, 40, 100))
+				fg, bg = c.FG, c.BG
+			}
+			r := c.R
+			if r == 0 {
+				r = ' '
+			}
+			sb.WriteRune(r)
+		}
+		if fg != viz.Default || bg != viz.Default {
+			sb.WriteString("\x1b[0m")
+		}
+		sb.WriteString("\x1b[K\r\n")
+	}
+	return sb.String()
+}
+
+// ansiColor returns the SGR parameter of c given the parameters of the default
+// color and the first normal and bright colors.
+func ansiColor(c viz.Color, def, normal, bright int) int {
+	switch {
+	case c == viz.Default || c > viz.BrightWhite:
+		return def
+	case c < viz.BrightBlack:
+		return normal + int(c-viz.Black)
+	}
+	return bright + int(c-viz.BrightBlack)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aod/elver/viz"
+)
+
+func TestRenderFrame(t *testing.T) {
+	f := viz.NewFrame(3, 1)
+	f.Set(1, 0, '#', viz.Red)
+	f.Cells[0][2] = viz.Cell{R: '@', FG: viz.BrightBlue, BG: viz.White}
+
+	want := " \x1b[31;49m#\x1b[94;47m@\x1b[0m\x1b[K\r\n"
+	if got := renderFrame(f); got != want {
+		t.Errorf("renderFrame() = %q, want %q", got, want)
+	}
+}
+
+func TestReadKeys(t *testing.T) {
+	keys := make(chan key)
+	go readKeys(strings.NewReader(" \x1b[C\x1b[Dx+q"), keys)
+
+	want := []key{keyPause, keyNext, keyPrev, keyFaster, keyQuit}
+	var got []key
+	for k := range keys {
+		got = append(got, k)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got keys %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got keys %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPlayerHandle(t *testing.T) {
+	p := &player{frames: make([]viz.Frame, 3), fps: 10}
+	for _, k := range []key{keyNext, keyNext, keyNext} {
+		p.handle(k)
+	}
+	if p.i != 2 || !p.paused {
+		t.Errorf("after stepping past the end at frame %d, paused %t, want 2 and true", p.i, p.paused)
+	}
+	p.handle(keyPause)
+	if p.i != 0 || p.paused {
+		t.Errorf("resuming at the end restarts at frame %d, paused %t, want 0 and false", p.i, p.paused)
+	}
+	p.handle(keySlower)
+	if p.fps != 5 {
+		t.Errorf("fps = %g, want 5", p.fps)
+	}
+	if !p.handle(keyQuit) {
+		t.Error("handle(keyQuit) = false, want true")
+	}
+}
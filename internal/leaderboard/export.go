@@ -0,0 +1,135 @@
+package leaderboard
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Tables which can be exported as CSV.
+const (
+	MembersTable = "members"
+	DaysTable    = "days"
+	HistoryTable = "history"
+)
+
+// WriteJSON writes s as indented JSON to w.
+func WriteJSON(w io.Writer, s *Stats) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// WriteCSV writes table of s as CSV to w. Durations are written in seconds.
+func WriteCSV(w io.Writer, s *Stats, table string) error {
+	var records [][]string
+	switch table {
+	case MembersTable:
+		records = append(records, []string{"id", "name", "stars", "local_score", "score",
+			"median_a", "median_b", "delta_min", "delta_median", "delta_max"})
+		for _, m := range s.Members {
+			records = append(records, []string{
+				strconv.FormatInt(m.ID, 10), m.Name, strconv.Itoa(m.Stars),
+				strconv.Itoa(m.LocalScore), strconv.Itoa(m.Score),
+				seconds(m.SolveA.Median, m.SolveA.N), seconds(m.SolveB.Median, m.SolveB.N),
+				seconds(m.Delta.Min, m.Delta.N), seconds(m.Delta.Median, m.Delta.N), seconds(m.Delta.Max, m.Delta.N),
+			})
+		}
+	case DaysTable:
+		records = append(records, []string{"day", "fastest_a", "fastest_a_time", "fastest_b", "fastest_b_time",
+			"delta_min", "delta_median", "delta_max"})
+		for _, d := range s.Days {
+			records = append(records, []string{
+				d.Day.String(), fastestName(d.FastestA), fastestTime(d.FastestA),
+				fastestName(d.FastestB), fastestTime(d.FastestB),
+				seconds(d.Delta.Min, d.Delta.N), seconds(d.Delta.Median, d.Delta.N), seconds(d.Delta.Max, d.Delta.N),
+			})
+		}
+	case HistoryTable:
+		records = append(records, []string{"member_id", "name", "day", "part", "time", "since_unlock"})
+		for _, r := range s.History {
+			records = append(records, []string{
+				strconv.FormatInt(r.MemberID, 10), r.Name, r.Day.String(), r.Part,
+				r.Time.Format(time.RFC3339), seconds(r.SinceUnlock, 1),
+			})
+		}
+	default:
+		return fmt.Errorf("unknown table %q, expected one of: %s, %s, %s", table, MembersTable, DaysTable, HistoryTable)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// WriteText writes s as human readable tables to w.
+func WriteText(w io.Writer, s *Stats) error {
+	fmt.Fprintf(w, "AOC %d leaderboard statistics (%s scoring)\n", s.Year, s.Scoring)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tSCORE\tSTARS\tMEDIAN A\tMEDIAN B\tMEDIAN DELTA\tNAME")
+	for i, m := range s.Members {
+		fmt.Fprintf(tw, "%d)\t%d\t%d\t%s\t%s\t%s\t%s\n", i+1, m.Score, m.Stars,
+			duration(m.SolveA.Median, m.SolveA.N), duration(m.SolveB.Median, m.SolveB.N),
+			duration(m.Delta.Median, m.Delta.N), m.Name)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tFASTEST A\t\tFASTEST B\t\tDELTA MIN\tMEDIAN\tMAX")
+	for _, d := range s.Days {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", d.Day,
+			fastestName(d.FastestA), fastestDuration(d.FastestA),
+			fastestName(d.FastestB), fastestDuration(d.FastestB),
+			duration(d.Delta.Min, d.Delta.N), duration(d.Delta.Median, d.Delta.N), duration(d.Delta.Max, d.Delta.N))
+	}
+	return tw.Flush()
+}
+
+// seconds formats d in seconds or returns an empty string when there are no
+// n values d was computed from.
+func seconds(d Duration, n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// duration formats d or returns "-" when there are no n values d was computed
+// from.
+func duration(d Duration, n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return d.String()
+}
+
+func fastestName(f *Fastest) string {
+	if f == nil {
+		return "-"
+	}
+	return f.Name
+}
+
+func fastestTime(f *Fastest) string {
+	if f == nil {
+		return ""
+	}
+	return seconds(f.Time, 1)
+}
+
+func fastestDuration(f *Fastest) string {
+	if f == nil {
+		return "-"
+	}
+	return f.Time.String()
+}
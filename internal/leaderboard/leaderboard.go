@@ -0,0 +1,92 @@
+// Package leaderboard computes analytics from private leaderboards.
+package leaderboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aod/elver/aoc"
+)
+
+// Duration is a time.Duration which is encoded in seconds for exports.
+type Duration time.Duration
+
+// MarshalJSON encodes d as a number of seconds.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Seconds())
+}
+
+// Seconds returns d as a number of seconds.
+func (d Duration) Seconds() float64 {
+	return time.Duration(d).Seconds()
+}
+
+// String formats d like the website does as hh:mm:ss.
+func (d Duration) String() string {
+	t := time.Duration(d).Round(time.Second)
+	h := t / time.Hour
+	t -= h * time.Hour
+	m := t / time.Minute
+	t -= m * time.Minute
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, t/time.Second)
+}
+
+// StarRecord is a single star earned by a member.
+type StarRecord struct {
+	MemberID int64     `json:"member_id"`
+	Name     string    `json:"name"`
+	Day      aoc.Day   `json:"day"`
+	Part     string    `json:"part"`
+	Time     time.Time `json:"time"`
+
+	// SinceUnlock is the time it took since the puzzle unlocked.
+	SinceUnlock Duration `json:"since_unlock"`
+}
+
+// History returns every star earned in l ordered by the time it was earned.
+func History(l *aoc.Leaderboard) ([]StarRecord, error) {
+	year, err := eventYear(l)
+	if err != nil {
+		return nil, err
+	}
+	event := aoc.Event(year)
+
+	var stars []StarRecord
+	for _, m := range l.Ranked() {
+		for _, day := range event.Days() {
+			for _, part := range []aoc.Part{aoc.Part1, aoc.Part2} {
+				t, ok := m.Star(day, part)
+				if !ok {
+					continue
+				}
+				stars = append(stars, StarRecord{
+					MemberID:    int64(m.ID),
+					Name:        m.DisplayName(),
+					Day:         day,
+					Part:        part.String(),
+					Time:        t.UTC(),
+					SinceUnlock: Duration(t.Sub(event.Unlock(day))),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(stars, func(i, j int) bool {
+		if !stars[i].Time.Equal(stars[j].Time) {
+			return stars[i].Time.Before(stars[j].Time)
+		}
+		return stars[i].MemberID < stars[j].MemberID
+	})
+	return stars, nil
+}
+
+func eventYear(l *aoc.Leaderboard) (aoc.Year, error) {
+	y, err := strconv.Atoi(l.Event)
+	if err != nil {
+		return 0, fmt.Errorf("invalid leaderboard event %q", l.Event)
+	}
+	return aoc.Year(y), nil
+}
@@ -0,0 +1,177 @@
+package leaderboard
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/aod/elver/aoc"
+)
+
+func fixture(t *testing.T) *aoc.Leaderboard {
+	b, err := ioutil.ReadFile("testdata/2020.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := aoc.ParseLeaderboard(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestScore(t *testing.T) {
+	l := fixture(t)
+
+	testCases := []struct {
+		scoring string
+		want    map[int64]int
+	}{
+		{scoring: "local", want: map[int64]int{1: 10, 2: 8, 3: 1}},
+		{scoring: "global", want: map[int64]int{1: 398, 2: 299, 3: 98}},
+		{scoring: "stars", want: map[int64]int{1: 4, 2: 3, 3: 1}},
+		{scoring: "podium", want: map[int64]int{1: 10, 2: 8, 3: 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scoring, func(t *testing.T) {
+			s, err := ScoringByName(tc.scoring)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Score(l, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for id, want := range tc.want {
+				if got[id] != want {
+					t.Errorf("member %d: expected %d, got %d", id, want, got[id])
+				}
+			}
+		})
+	}
+
+	if _, err := ScoringByName("unknown"); err == nil {
+		t.Error("expected an error for an unknown scoring")
+	}
+}
+
+func TestLocalScoreMatchesWebsite(t *testing.T) {
+	l := fixture(t)
+	scores, err := Score(l, Scorings["local"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range l.Members {
+		if got := scores[int64(m.ID)]; got != m.LocalScore {
+			t.Errorf("%s: expected local score %d, got %d", m.DisplayName(), m.LocalScore, got)
+		}
+	}
+}
+
+func TestCompute(t *testing.T) {
+	s, err := Compute(fixture(t), "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sec := func(n int) Duration { return Duration(time.Duration(n) * time.Second) }
+
+	if len(s.Members) != 3 {
+		t.Fatalf("expected 3 members, got %d", len(s.Members))
+	}
+	alice, bob := s.Members[0], s.Members[1]
+	if alice.Name != "alice" || bob.Name != "bob" {
+		t.Fatalf("expected alice and bob first, got %s and %s", alice.Name, bob.Name)
+	}
+	if alice.SolveA.Median != sec(750) || alice.SolveB.Median != sec(1050) {
+		t.Errorf("alice: unexpected medians %s and %s", alice.SolveA.Median, alice.SolveB.Median)
+	}
+	if bob.Delta.N != 1 || bob.Delta.Median != sec(600) {
+		t.Errorf("bob: unexpected delta distribution %+v", bob.Delta)
+	}
+	if s.Members[2].Name != "(anonymous user #3)" || s.Members[2].SolveB.N != 0 {
+		t.Errorf("unexpected anonymous member %+v", s.Members[2])
+	}
+
+	if len(s.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(s.Days))
+	}
+	d1 := s.Days[0]
+	if d1.FastestA.Name != "bob" || d1.FastestA.Time != sec(200) {
+		t.Errorf("day 1: unexpected fastest A %+v", d1.FastestA)
+	}
+	if d1.FastestB.Name != "alice" || d1.FastestB.Time != sec(600) {
+		t.Errorf("day 1: unexpected fastest B %+v", d1.FastestB)
+	}
+	if d1.Delta.Median != sec(450) || d1.Delta.Min != sec(300) || d1.Delta.Max != sec(600) {
+		t.Errorf("day 1: unexpected delta distribution %+v", d1.Delta)
+	}
+
+	if len(s.History) != 8 {
+		t.Fatalf("expected 8 stars, got %d", len(s.History))
+	}
+	if first := s.History[0]; first.Name != "bob" || first.SinceUnlock != sec(200) {
+		t.Errorf("unexpected first star %+v", first)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	s, err := Compute(fixture(t), "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for table, rows := range map[string]int{MembersTable: 4, DaysTable: 3, HistoryTable: 9} {
+		var buf bytes.Buffer
+		if err := WriteCSV(&buf, s, table); err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != rows {
+			t.Errorf("%s: expected %d rows, got %d", table, rows, len(records))
+		}
+	}
+
+	var buf bytes.Buffer
+	WriteCSV(&buf, s, MembersTable)
+	records, _ := csv.NewReader(&buf).ReadAll()
+	if got := records[1][5]; got != "750" {
+		t.Errorf("expected alice's median A of 750 seconds, got %s", got)
+	}
+
+	if err := WriteCSV(&buf, s, "unknown"); err == nil {
+		t.Error("expected an error for an unknown table")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	s, err := Compute(fixture(t), "local")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Members []struct {
+			Name   string
+			SolveA struct{ Median float64 } `json:"solve_a"`
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Members[0].Name != "alice" || got.Members[0].SolveA.Median != 750 {
+		t.Errorf("unexpected JSON member %+v", got.Members[0])
+	}
+}
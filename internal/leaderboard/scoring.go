@@ -0,0 +1,89 @@
+package leaderboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aod/elver/aoc"
+)
+
+// Scoring awards points for a star given the 0-based rank at which it was
+// earned and the number of members n on the leaderboard.
+type Scoring func(rank, n int) int
+
+// Scorings contains the supported scoring rules by name.
+var Scorings = map[string]Scoring{
+	// local is the scoring used by private leaderboards.
+	"local": func(rank, n int) int { return n - rank },
+	// global is the scoring used by the global leaderboard where only the
+	// first hundred get points.
+	"global": func(rank, n int) int {
+		if rank >= 100 {
+			return 0
+		}
+		return 100 - rank
+	},
+	// stars awards a point for every star regardless of rank.
+	"stars": func(rank, n int) int { return 1 },
+	// podium only awards the first three stars of every part.
+	"podium": func(rank, n int) int {
+		if rank >= 3 {
+			return 0
+		}
+		return 3 - rank
+	},
+}
+
+// ScoringByName returns the scoring rule called name.
+func ScoringByName(name string) (Scoring, error) {
+	s, ok := Scorings[name]
+	if !ok {
+		names := make([]string, 0, len(Scorings))
+		for n := range Scorings {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown scoring %q, expected one of: %s", name, strings.Join(names, ", "))
+	}
+	return s, nil
+}
+
+// Score recomputes the score of every member of l by the id of the member.
+func Score(l *aoc.Leaderboard, s Scoring) (map[int64]int, error) {
+	year, err := eventYear(l)
+	if err != nil {
+		return nil, err
+	}
+
+	members := l.Ranked()
+	scores := make(map[int64]int, len(members))
+	for _, m := range members {
+		scores[int64(m.ID)] = 0
+	}
+
+	for _, day := range aoc.Event(year).Days() {
+		for _, part := range []aoc.Part{aoc.Part1, aoc.Part2} {
+			type star struct {
+				id int64
+				ts int64
+			}
+			var stars []star
+			for _, m := range members {
+				if t, ok := m.Star(day, part); ok {
+					stars = append(stars, star{int64(m.ID), t.Unix()})
+				}
+			}
+			sort.Slice(stars, func(i, j int) bool {
+				if stars[i].ts != stars[j].ts {
+					return stars[i].ts < stars[j].ts
+				}
+				return stars[i].id < stars[j].id
+			})
+			for rank, st := range stars {
+				scores[st.id] += s(rank, len(members))
+			}
+		}
+	}
+	return scores, nil
+}
@@ -0,0 +1,180 @@
+package leaderboard
+
+import (
+	"sort"
+	"time"
+
+	"github.com/aod/elver/aoc"
+)
+
+// Distribution summarizes a set of durations.
+type Distribution struct {
+	N      int      `json:"n"`
+	Min    Duration `json:"min"`
+	P25    Duration `json:"p25"`
+	Median Duration `json:"median"`
+	P75    Duration `json:"p75"`
+	Max    Duration `json:"max"`
+}
+
+// distribution returns the Distribution of ds. The slice is sorted in place.
+func distribution(ds []time.Duration) Distribution {
+	if len(ds) == 0 {
+		return Distribution{}
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	return Distribution{
+		N:      len(ds),
+		Min:    Duration(ds[0]),
+		P25:    Duration(quantile(ds, 0.25)),
+		Median: Duration(quantile(ds, 0.5)),
+		P75:    Duration(quantile(ds, 0.75)),
+		Max:    Duration(ds[len(ds)-1]),
+	}
+}
+
+// quantile returns the q-quantile of the sorted ds using linear interpolation
+// between the closest ranks.
+func quantile(ds []time.Duration, q float64) time.Duration {
+	pos := q * float64(len(ds)-1)
+	i := int(pos)
+	if i+1 >= len(ds) {
+		return ds[i]
+	}
+	frac := pos - float64(i)
+	return ds[i] + time.Duration(frac*float64(ds[i+1]-ds[i]))
+}
+
+// MemberStats holds the statistics of a single member.
+type MemberStats struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Stars int    `json:"stars"`
+
+	// LocalScore is the score as reported by the website and Score the score
+	// recomputed with the selected scoring rule.
+	LocalScore int `json:"local_score"`
+	Score      int `json:"score"`
+
+	// SolveA and SolveB are the times it took since unlock to earn the stars
+	// of both parts.
+	SolveA Distribution `json:"solve_a"`
+	SolveB Distribution `json:"solve_b"`
+
+	// Delta is the time between earning the first and second star of a day.
+	Delta Distribution `json:"delta"`
+}
+
+// DayStats holds the statistics of a single day.
+type DayStats struct {
+	Day aoc.Day `json:"day"`
+
+	// FastestA and FastestB are the members who were the first to earn the
+	// star of each part.
+	FastestA *Fastest `json:"fastest_a"`
+	FastestB *Fastest `json:"fastest_b"`
+
+	Delta Distribution `json:"delta"`
+}
+
+// Fastest is the member who was first to earn a star.
+type Fastest struct {
+	Name string   `json:"name"`
+	Time Duration `json:"time"`
+}
+
+// Stats holds the statistics of a private leaderboard.
+type Stats struct {
+	Year    aoc.Year      `json:"year"`
+	Scoring string        `json:"scoring"`
+	Members []MemberStats `json:"members"`
+	Days    []DayStats    `json:"days"`
+	History []StarRecord  `json:"history"`
+}
+
+// Compute computes the statistics of l where the score of every member is
+// recomputed using the scoring rule called scoring. Members are ordered by
+// their recomputed score.
+func Compute(l *aoc.Leaderboard, scoring string) (*Stats, error) {
+	year, err := eventYear(l)
+	if err != nil {
+		return nil, err
+	}
+	rule, err := ScoringByName(scoring)
+	if err != nil {
+		return nil, err
+	}
+	history, err := History(l)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := Score(l, rule)
+	if err != nil {
+		return nil, err
+	}
+	event := aoc.Event(year)
+
+	stats := &Stats{Year: year, Scoring: scoring, History: history}
+	for _, m := range l.Ranked() {
+		var a, b, delta []time.Duration
+		for _, day := range event.Days() {
+			ta, okA := m.Star(day, aoc.Part1)
+			tb, okB := m.Star(day, aoc.Part2)
+			if okA {
+				a = append(a, ta.Sub(event.Unlock(day)))
+			}
+			if okB {
+				b = append(b, tb.Sub(event.Unlock(day)))
+			}
+			if okA && okB {
+				delta = append(delta, tb.Sub(ta))
+			}
+		}
+
+		stats.Members = append(stats.Members, MemberStats{
+			ID:         int64(m.ID),
+			Name:       m.DisplayName(),
+			Stars:      m.Stars,
+			LocalScore: m.LocalScore,
+			Score:      scores[int64(m.ID)],
+			SolveA:     distribution(a),
+			SolveB:     distribution(b),
+			Delta:      distribution(delta),
+		})
+	}
+	sort.SliceStable(stats.Members, func(i, j int) bool {
+		return stats.Members[i].Score > stats.Members[j].Score
+	})
+
+	for _, day := range event.Days() {
+		ds := DayStats{Day: day}
+		var delta []time.Duration
+		for _, m := range l.Ranked() {
+			ta, okA := m.Star(day, aoc.Part1)
+			tb, okB := m.Star(day, aoc.Part2)
+			if okA {
+				ds.FastestA = faster(ds.FastestA, m, ta.Sub(event.Unlock(day)))
+			}
+			if okB {
+				ds.FastestB = faster(ds.FastestB, m, tb.Sub(event.Unlock(day)))
+			}
+			if okA && okB {
+				delta = append(delta, tb.Sub(ta))
+			}
+		}
+		if ds.FastestA == nil {
+			continue
+		}
+		ds.Delta = distribution(delta)
+		stats.Days = append(stats.Days, ds)
+	}
+
+	return stats, nil
+}
+
+func faster(f *Fastest, m aoc.Member, d time.Duration) *Fastest {
+	if f != nil && time.Duration(f.Time) <= d {
+		return f
+	}
+	return &Fastest{Name: m.DisplayName(), Time: Duration(d)}
+}
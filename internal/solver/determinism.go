@@ -0,0 +1,139 @@
+package solver
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/aod/elver/aoc"
+)
+
+// Variation is a runtime configuration a solver runs under while checking
+// whether its answer is deterministic.
+type Variation struct {
+	GOMAXPROCS int
+	GCPercent  int
+}
+
+func (v Variation) String() string {
+	return fmt.Sprintf("GOMAXPROCS=%d GOGC=%d", v.GOMAXPROCS, v.GCPercent)
+}
+
+// apply configures the runtime as v and returns a function which restores the
+// previous configuration.
+func (v Variation) apply() func() {
+	procs := runtime.GOMAXPROCS(v.GOMAXPROCS)
+	gc := debug.SetGCPercent(v.GCPercent)
+	return func() {
+		runtime.GOMAXPROCS(procs)
+		debug.SetGCPercent(gc)
+	}
+}
+
+// Variations returns the runtime configurations to cycle through, combining
+// the current GOMAXPROCS and a single P with the default, an aggressive and a
+// lazy garbage collector.
+func Variations() []Variation {
+	procs := []int{runtime.GOMAXPROCS(0)}
+	if procs[0] > 1 {
+		procs = append(procs, 1)
+	}
+
+	var vs []Variation
+	for _, gc := range []int{100, 10, 400} {
+		for _, p := range procs {
+			vs = append(vs, Variation{GOMAXPROCS: p, GCPercent: gc})
+		}
+	}
+	return vs
+}
+
+// Outcome is a distinct answer, or error, given by a solver.
+type Outcome struct {
+	Text string
+	Runs int
+	// First is the variation the outcome was first seen with, nil when the
+	// runtime configuration was not varied.
+	First *Variation
+}
+
+// Determinism is the result of running a solver multiple times on the same
+// input.
+type Determinism struct {
+	aoc.DatePart
+	Variant  string
+	Runs     int
+	Outcomes []Outcome
+}
+
+// Deterministic reports whether every run gave the same answer.
+func (d Determinism) Deterministic() bool {
+	return len(d.Outcomes) == 1
+}
+
+func (d Determinism) String() string {
+	res := fmt.Sprintf("Day %s %s ", d.Day, partName(d.Part, d.Variant))
+	if d.Deterministic() {
+		return res + fmt.Sprintf("deterministic over %d runs:\n%s", d.Runs, d.Outcomes[0].Text)
+	}
+
+	res += fmt.Sprintf("NOT deterministic over %d runs, %d distinct answers:", d.Runs, len(d.Outcomes))
+	for _, o := range d.Outcomes {
+		text := o.Text
+		if strings.Contains(text, "\n") {
+			text = strconv.Quote(text)
+		}
+		res += fmt.Sprintf("\n%s (%d %s", text, o.Runs, plural(o.Runs, "run"))
+		if o.First != nil {
+			res += ", first with " + o.First.String()
+		}
+		res += ")"
+	}
+	return res
+}
+
+// CheckDeterminism runs the solver runs times on input and collects the
+// distinct answers. When vs is given every run uses the next variation of the
+// runtime configuration. The runtime configuration is process wide so no other
+// solvers may run at the same time.
+func (s Solver) CheckDeterminism(input string, runs int, vs []Variation) Determinism {
+	d := Determinism{DatePart: s.DatePart, Variant: s.Variant, Runs: runs}
+	seen := make(map[string]int)
+	for i := 0; i < runs; i++ {
+		var v *Variation
+		if len(vs) > 0 {
+			v = &vs[i%len(vs)]
+		}
+
+		text := s.outcome(input, v)
+		if j, ok := seen[text]; ok {
+			d.Outcomes[j].Runs++
+			continue
+		}
+		seen[text] = len(d.Outcomes)
+		d.Outcomes = append(d.Outcomes, Outcome{Text: text, Runs: 1, First: v})
+	}
+	return d
+}
+
+func (s Solver) outcome(input string, v *Variation) string {
+	if v != nil {
+		defer v.apply()()
+	}
+	var answer Output
+	var err error
+	s.quiet(func() { answer, err = s.Solve(input) })
+	if err != nil {
+		return "[ERROR] " + err.Error()
+	}
+	return Result{Answer: answer}.Text()
+}
+
+func plural(n int, s string) string {
+	if n == 1 {
+		return s
+	}
+	return s + "s"
+}
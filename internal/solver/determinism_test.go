@@ -0,0 +1,31 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckDeterminism(t *testing.T) {
+	calls := 0
+	s := Solver{Solver: Adapt(func(string) (Output, error) {
+		calls++
+		if calls%3 == 0 {
+			return nil, errors.New("boom")
+		}
+		return calls % 2, nil
+	})}
+
+	d := s.CheckDeterminism("", 6, Variations())
+	if d.Deterministic() {
+		t.Fatal("Deterministic() = true, want false")
+	}
+	want := []Outcome{{Text: "1", Runs: 2}, {Text: "0", Runs: 2}, {Text: "[ERROR] boom", Runs: 2}}
+	if len(d.Outcomes) != len(want) {
+		t.Fatalf("got %d outcomes, want %d", len(d.Outcomes), len(want))
+	}
+	for i, o := range d.Outcomes {
+		if o.Text != want[i].Text || o.Runs != want[i].Runs || o.First == nil {
+			t.Errorf("outcome %d = %+v, want %+v with a variation", i, o, want[i])
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package solver
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aod/elver/aoc"
+)
+
+var (
+	solverRe = regexp.MustCompile(`^Day([1-9][0-9]?)([AB])(?:_(\w+))?$`)
+	// nearMissRe matches names which look like a solver, e.g. Day1a, Day01A,
+	// day1A, Day_1_A or Day1_2. A numbered part needs the underscore so
+	// helpers like Day12 are not mistaken for Day1B.
+	nearMissRe = regexp.MustCompile(`(?i)^day_?0*([0-9]+)(?:_?([ab])|_([12]))(?:_(\w+))?$`)
+
+	// partRe and nearMissPartRe are the same for the solvers in the package
+	// of a single day which may leave out the day, e.g. PartA_naive.
+	partRe         = regexp.MustCompile(`^Part([AB])(?:_(\w+))?$`)
+	nearMissPartRe = regexp.MustCompile(`(?i)^part_?([ab12])(?:_(\w+))?$`)
+)
+
+// Declared is a solver declared in the source of a year or day.
+type Declared struct {
+	aoc.DatePart
+	// Name is the name of the solver function, e.g. Day7A_naive or PartA.
+	Name string
+	// Variant is the name of the variant, e.g. naive for Day7A_naive.
+	Variant string
+	Pos     token.Position
+}
+
+// Warning is a problem with a function which looks like a solver.
+type Warning struct {
+	Pos token.Position
+	Msg string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Pos, w.Msg)
+}
+
+// Discovery holds the solvers declared in the Go files of a year or day
+// directory.
+type Discovery struct {
+	Solvers  []Declared
+	Warnings []Warning
+}
+
+// Discover parses the Go files of the directory of year and finds the solvers
+// it declares without building it. Functions which look like a solver but are
+// misnamed or have the wrong signature are reported as warnings, as are files
+// which can not be parsed.
+func Discover(dir string, year aoc.Year) (Discovery, error) {
+	return discover(dir, func(d *Discovery, pos token.Position, fn *ast.FuncDecl, logger string) {
+		d.check(pos, fn, year, logger)
+	})
+}
+
+// DiscoverDay is Discover for the directory of a single day of which the
+// solvers are named PartA and PartB, or after the day like those of a year.
+// A solver named after the day is shadowed by one named after its part.
+func DiscoverDay(dir string, date aoc.Date) (Discovery, error) {
+	d, err := discover(dir, func(d *Discovery, pos token.Position, fn *ast.FuncDecl, logger string) {
+		d.checkPart(pos, fn, date, logger)
+	})
+	if err != nil {
+		return d, err
+	}
+
+	parts := make(map[string]string)
+	for _, s := range d.Solvers {
+		if strings.HasPrefix(s.Name, "Part") {
+			parts[s.Part.String()+"_"+s.Variant] = s.Name
+		}
+	}
+	solvers := d.Solvers[:0]
+	for _, s := range d.Solvers {
+		if name, ok := parts[s.Part.String()+"_"+s.Variant]; ok && name != s.Name {
+			d.warn(s.Pos, "%s is shadowed by %s", s.Name, name)
+			continue
+		}
+		solvers = append(solvers, s)
+	}
+	d.Solvers = solvers
+	return d, nil
+}
+
+// discover parses the Go files of dir and checks its functions with check. It
+// is given the name the logger package is imported as in the file of the
+// function, if it is imported.
+func discover(dir string, check func(d *Discovery, pos token.Position, fn *ast.FuncDecl, logger string)) (Discovery, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return Discovery{}, err
+	}
+
+	var d Discovery
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if list, ok := err.(scanner.ErrorList); ok && len(list) > 0 {
+			d.warn(list[0].Pos, "%s", list[0].Msg)
+			continue
+		} else if err != nil {
+			d.warn(token.Position{Filename: file}, "%s", err)
+			continue
+		}
+		logger := loggerImport(f)
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				check(&d, fset.Position(fn.Name.Pos()), fn, logger)
+			}
+		}
+	}
+
+	sort.Slice(d.Solvers, func(i, j int) bool {
+		a, b := d.Solvers[i], d.Solvers[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Part != b.Part {
+			return a.Part < b.Part
+		}
+		if a.Variant != b.Variant {
+			return a.Variant < b.Variant
+		}
+		return a.Name < b.Name
+	})
+	d.checkVariants()
+	return d, nil
+}
+
+func (d *Discovery) warn(pos token.Position, format string, args ...interface{}) {
+	d.Warnings = append(d.Warnings, Warning{Pos: pos, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (d *Discovery) check(pos token.Position, fn *ast.FuncDecl, year aoc.Year, logger string) {
+	name := fn.Name.Name
+	if m := solverRe.FindStringSubmatch(name); m != nil {
+		day, _ := strconv.Atoi(m[1])
+		d.add(pos, fn, aoc.DatePart{Date: aoc.Date{Year: year, Day: aoc.Day(day)}, Part: aoc.Part(m[2][0])}, m[3], logger)
+		return
+	}
+
+	if m := nearMissRe.FindStringSubmatch(name); m != nil {
+		want := "Day" + m[1] + nearMissPart(m[2]+m[3]) + variantSuffix(m[4])
+		if solverRe.MatchString(want) {
+			d.warn(pos, "%s looks like a solver, did you mean %s?", name, want)
+		} else {
+			d.warn(pos, "%s looks like a solver but day %s does not exist", name, m[1])
+		}
+	}
+}
+
+// checkPart is check for the package of a single day.
+func (d *Discovery) checkPart(pos token.Position, fn *ast.FuncDecl, date aoc.Date, logger string) {
+	name := fn.Name.Name
+	if m := partRe.FindStringSubmatch(name); m != nil {
+		d.add(pos, fn, aoc.DatePart{Date: date, Part: aoc.Part(m[1][0])}, m[2], logger)
+		return
+	}
+	if m := solverRe.FindStringSubmatch(name); m != nil {
+		if m[1] != date.Day.String() {
+			d.warn(pos, "%s is in the directory of day %s", name, date.Day)
+			return
+		}
+		d.add(pos, fn, aoc.DatePart{Date: date, Part: aoc.Part(m[2][0])}, m[3], logger)
+		return
+	}
+
+	if m := nearMissPartRe.FindStringSubmatch(name); m != nil {
+		d.warn(pos, "%s looks like a solver, did you mean %s?", name, "Part"+nearMissPart(m[1])+variantSuffix(m[2]))
+	} else if m := nearMissRe.FindStringSubmatch(name); m != nil {
+		d.warn(pos, "%s looks like a solver, did you mean %s?", name, "Day"+date.Day.String()+nearMissPart(m[2]+m[3])+variantSuffix(m[4]))
+	}
+}
+
+// add adds fn as the solver of dp unless it can not be one.
+func (d *Discovery) add(pos token.Position, fn *ast.FuncDecl, dp aoc.DatePart, variant, logger string) {
+	name := fn.Name.Name
+	event := aoc.Event(dp.Year)
+	switch {
+	case fn.Recv != nil:
+		d.warn(pos, "%s is a method, solvers must be functions", name)
+	case !event.Has(dp.Day):
+		d.warn(pos, "%s: %s has no day %s", name, dp.Year, dp.Day)
+	case dp.Part == aoc.Part2 && event.SinglePart(dp.Day):
+		d.warn(pos, "%s: the final day only has a single puzzle", name)
+	case !validSignature(fn.Type, logger):
+		d.warn(pos, "%s has signature %s, expected func(string) (interface{}, error) or func(string, *logger.Logger) (interface{}, error)",
+			name, types.ExprString(fn.Type))
+	default:
+		d.Solvers = append(d.Solvers, Declared{DatePart: dp, Name: name, Variant: variant, Pos: pos})
+	}
+}
+
+// nearMissPart returns the part meant by the part of a near miss.
+func nearMissPart(s string) string {
+	switch s = strings.ToUpper(s); s {
+	case "1":
+		return aoc.Part1.String()
+	case "2":
+		return aoc.Part2.String()
+	}
+	return s
+}
+
+func variantSuffix(variant string) string {
+	if variant == "" {
+		return ""
+	}
+	return "_" + variant
+}
+
+// checkVariants warns about variants of parts without a solver.
+func (d *Discovery) checkVariants() {
+	primary := make(map[aoc.DatePart]bool)
+	for _, s := range d.Solvers {
+		if s.Variant == "" {
+			primary[s.DatePart] = true
+		}
+	}
+	for _, s := range d.Solvers {
+		if s.Variant != "" && !primary[s.DatePart] {
+			d.warn(s.Pos, "%s is a variant of %s which does not exist", s.Name, strings.TrimSuffix(s.Name, "_"+s.Variant))
+		}
+	}
+}
+
+// loggerPath is the import path of the package of the logger of a LogFunc.
+const loggerPath = "github.com/aod/elver/logger"
+
+// loggerImport returns the name the logger package is imported as in f, "."
+// for a dot import or "" when it is not imported.
+func loggerImport(f *ast.File) string {
+	for _, imp := range f.Imports {
+		if path, err := strconv.Unquote(imp.Path.Value); err != nil || path != loggerPath {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+		return "logger"
+	}
+	return ""
+}
+
+// validSignature reports whether t is the type of a Func or LogFunc where the
+// logger package is imported as logger, see loggerImport. Types are compared
+// by name since the source is not type checked.
+func validSignature(t *ast.FuncType, logger string) bool {
+	var params []ast.Expr
+	for _, f := range t.Params.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			params = append(params, f.Type)
+		}
+	}
+	var results []ast.Expr
+	if t.Results != nil {
+		for _, f := range t.Results.List {
+			n := len(f.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				results = append(results, f.Type)
+			}
+		}
+	}
+
+	switch {
+	case len(params) < 1 || len(params) > 2 || len(results) != 2:
+		return false
+	case types.ExprString(params[0]) != "string":
+		return false
+	case len(params) == 2 && !isLogger(params[1], logger):
+		return false
+	}
+	out := types.ExprString(results[0])
+	return (out == "interface{}" || out == "any") && types.ExprString(results[1]) == "error"
+}
+
+// isLogger reports whether t is *logger.Logger with the logger package
+// imported as logger.
+func isLogger(t ast.Expr, logger string) bool {
+	star, ok := t.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	switch x := star.X.(type) {
+	case *ast.Ident:
+		return logger == "." && x.Name == "Logger"
+	case *ast.SelectorExpr:
+		pkg, ok := x.X.(*ast.Ident)
+		return ok && logger != "" && logger != "." && logger != "_" && pkg.Name == logger && x.Sel.Name == "Logger"
+	}
+	return false
+}
+
+// Variants returns the variants of the solver of dp.
+func (d Discovery) Variants(dp aoc.DatePart) []Declared {
+	var variants []Declared
+	for _, s := range d.Solvers {
+		if s.DatePart == dp && s.Variant != "" {
+			variants = append(variants, s)
+		}
+	}
+	return variants
+}
@@ -0,0 +1,211 @@
+package solver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aod/elver/aoc"
+)
+
+const discoverSrc = `package main
+
+import "github.com/aod/elver/logger"
+
+func Day7A(input string) (interface{}, error)                    { return nil, nil }
+func Day7A_naive(input string) (interface{}, error)              { return nil, nil }
+func Day7A_fast(input string, log *logger.Logger) (any, error)   { return nil, nil }
+func Day7B_v2(input string) (interface{}, error)                 { return nil, nil }
+func Day1a(input string) (interface{}, error)                    { return nil, nil }
+func Day01A(input string) (interface{}, error)                   { return nil, nil }
+func day1B(input string) (interface{}, error)                    { return nil, nil }
+func Day26A(input string) (interface{}, error)                   { return nil, nil }
+func Day2A(input string) int                                     { return 0 }
+func Day25B(input string) (interface{}, error)                   { return nil, nil }
+func Helper()                                                    {}
+
+type T struct{}
+
+func (T) Day3A(input string) (interface{}, error) { return nil, nil }
+`
+
+func TestDiscover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"07.go":      discoverSrc,
+		"07_test.go": "package main\n\nfunc Day7A_test(input string) (interface{}, error) { return nil, nil }\n",
+		"broken.go":  "package main\n\nfunc {",
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := Discover(dir, 2020)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, s := range d.Solvers {
+		names = append(names, s.Name)
+	}
+	if want := []string{"Day7A", "Day7A_fast", "Day7A_naive", "Day7B_v2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("solvers = %v, want %v", names, want)
+	}
+	if got := d.Solvers[0].Pos.Line; got != 5 {
+		t.Errorf("Day7A is on line %d, want 5", got)
+	}
+
+	var variants []string
+	for _, v := range d.Variants(aoc.DatePart{Date: aoc.Date{Year: 2020, Day: 7}, Part: aoc.Part1}) {
+		variants = append(variants, v.Variant)
+	}
+	if want := []string{"fast", "naive"}; !reflect.DeepEqual(variants, want) {
+		t.Errorf("variants of Day7A = %v, want %v", variants, want)
+	}
+
+	var lines []int
+	for _, w := range d.Warnings {
+		if filepath.Base(w.Pos.Filename) == "07.go" {
+			lines = append(lines, w.Pos.Line)
+		}
+	}
+	// Day1a, Day01A, day1B, Day26A, Day2A, Day25B, the method Day3A and the
+	// variant Day7B_v2 without Day7B.
+	if want := []int{9, 10, 11, 12, 13, 14, 19, 8}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("warnings on lines %v, want %v:\n%v", lines, want, d.Warnings)
+	}
+	if len(d.Warnings) != len(lines)+1 {
+		t.Errorf("got %d warnings, want one for broken.go:\n%v", len(d.Warnings), d.Warnings)
+	}
+	if d.Warnings[1].Msg != "Day01A looks like a solver, did you mean Day1A?" {
+		t.Errorf("unexpected warning %q", d.Warnings[1].Msg)
+	}
+}
+
+const discoverDaySrc = `package main
+
+func PartA(input string) (interface{}, error)       { return nil, nil }
+func Day5A(input string) (interface{}, error)       { return nil, nil }
+func Day5B(input string) (interface{}, error)       { return nil, nil }
+func PartB_fast(input string) (interface{}, error)  { return nil, nil }
+func Day6A(input string) (interface{}, error)       { return nil, nil }
+func Parta(input string) (interface{}, error)       { return nil, nil }
+func day5b_naive(input string) (interface{}, error) { return nil, nil }
+`
+
+func TestDiscoverDay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "main.go"), []byte(discoverDaySrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := DiscoverDay(dir, aoc.Date{Year: 2020, Day: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, s := range d.Solvers {
+		names = append(names, s.Name)
+	}
+	if want := []string{"PartA", "Day5B", "PartB_fast"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("solvers = %v, want %v", names, want)
+	}
+
+	var msgs []string
+	for _, w := range d.Warnings {
+		msgs = append(msgs, w.Msg)
+	}
+	want := []string{
+		"Day6A is in the directory of day 5",
+		"Parta looks like a solver, did you mean PartA?",
+		"day5b_naive looks like a solver, did you mean Day5B_naive?",
+		"Day5A is shadowed by PartA",
+	}
+	if !reflect.DeepEqual(msgs, want) {
+		t.Errorf("warnings = %q, want %q", msgs, want)
+	}
+}
+
+func TestDiscoverSignatures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "elver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.go": `package main
+
+import "github.com/aod/elver/logger"
+
+func Day1A(input string, log *logger.Logger) (interface{}, error) { return nil, nil }
+func Day1B(input string, log logger.Logger) (interface{}, error)  { return nil, nil }
+func Day2A(input string, log *x.Logger) (interface{}, error)      { return nil, nil }
+func Day12(n int) int                                             { return n }
+func day11()                                                      {}
+func Day2_2(input string) (interface{}, error)                    { return nil, nil }
+`,
+		"b.go": `package main
+
+import lg "github.com/aod/elver/logger"
+
+func Day3A(input string, log *lg.Logger) (interface{}, error)     { return nil, nil }
+func Day3B(input string, log *logger.Logger) (interface{}, error) { return nil, nil }
+`,
+		"c.go": `package main
+
+import . "github.com/aod/elver/logger"
+
+func Day4A(input string, log *Logger) (interface{}, error) { return nil, nil }
+`,
+		"d.go": `package main
+
+func Day5A(input string, log *logger.Logger) (interface{}, error) { return nil, nil }
+`,
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d, err := Discover(dir, 2020)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, s := range d.Solvers {
+		names = append(names, s.Name)
+	}
+	if want := []string{"Day1A", "Day3A", "Day4A"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("solvers = %v, want %v", names, want)
+	}
+
+	var warned []string
+	for _, w := range d.Warnings {
+		warned = append(warned, strings.Fields(w.Msg)[0])
+	}
+	if want := []string{"Day1B", "Day2A", "Day2_2", "Day3B", "Day5A"}; !reflect.DeepEqual(warned, want) {
+		t.Errorf("warnings about %v, want %v:\n%v", warned, want, d.Warnings)
+	}
+	if d.Warnings[2].Msg != "Day2_2 looks like a solver, did you mean Day2B?" {
+		t.Errorf("unexpected warning %q", d.Warnings[2].Msg)
+	}
+}
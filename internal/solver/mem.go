@@ -0,0 +1,128 @@
+package solver
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// peakHeapInterval is how often the heap is sampled to approximate its peak.
+// Sampling stops the world so it should not be too frequent. Solvers which
+// finish within the interval are not sampled at all.
+const peakHeapInterval = 10 * time.Millisecond
+
+// MemStats holds the memory statistics of a single solve. The statistics are
+// process wide so they include the work of solvers running in parallel.
+type MemStats struct {
+	Bytes      uint64        `json:"bytes"`       // bytes allocated
+	Mallocs    uint64        `json:"mallocs"`     // heap objects allocated
+	NumGC      uint32        `json:"num_gc"`      // completed GC cycles
+	PauseTotal time.Duration `json:"pause_total"` // GC stop-the-world pauses
+	// PeakHeapGrowth approximates how far the heap grew beyond its size
+	// before the solve.
+	PeakHeapGrowth uint64 `json:"peak_heap_growth"`
+	// SamplePause is how long sampling the peak heap stopped the world. It
+	// is excluded from the time of the solve.
+	SamplePause time.Duration `json:"sample_pause"`
+}
+
+func (m MemStats) String() string {
+	s := fmt.Sprintf("%s in %d allocs, %d GCs", formatBytes(m.Bytes), m.Mallocs, m.NumGC)
+	if m.NumGC > 0 {
+		s += fmt.Sprintf(" pausing %s", m.PauseTotal)
+	}
+	s += ", peak heap growth " + formatBytes(m.PeakHeapGrowth)
+	if m.SamplePause > 0 {
+		s += fmt.Sprintf(" (sampling %s excluded)", m.SamplePause)
+	}
+	return s
+}
+
+// measure runs f and returns the time it took together with its memory
+// statistics. The time spent sampling the peak heap, during which f is
+// stopped, is not part of the returned time. A garbage collection runs before
+// f, outside of the time and statistics.
+func measure(f func()) (time.Duration, MemStats) {
+	// Everything the sampler needs is allocated up front so it does not show
+	// up in the statistics.
+	var before, after, m runtime.MemStats
+	type sampled struct {
+		max   uint64
+		pause time.Duration
+		// last and lastPause are the start and pause of the last sample
+		// which may have been taken after f returned.
+		last      time.Time
+		lastPause time.Duration
+	}
+	peak := make(chan sampled)
+	stop := make(chan struct{})
+	t := time.NewTicker(peakHeapInterval)
+	defer t.Stop()
+	go func() {
+		var s sampled
+		for {
+			select {
+			case <-t.C:
+				s.last = time.Now()
+				runtime.ReadMemStats(&m)
+				s.lastPause = time.Since(s.last)
+				s.pause += s.lastPause
+				if m.HeapAlloc > s.max {
+					s.max = m.HeapAlloc
+				}
+			case <-stop:
+				peak <- s
+				return
+			}
+		}
+	}()
+
+	// Collecting first makes the heap before the solve its live heap, so
+	// garbage of earlier solves does not hide the growth.
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	f()
+	elapsed := time.Since(start)
+
+	close(stop)
+	s := <-peak
+	max := s.max
+	if s.last.After(start.Add(elapsed)) {
+		s.pause -= s.lastPause
+	}
+	if s.pause < elapsed {
+		elapsed -= s.pause
+	}
+	runtime.ReadMemStats(&after)
+	if after.HeapAlloc > max {
+		max = after.HeapAlloc
+	}
+	var growth uint64
+	if max > before.HeapAlloc {
+		growth = max - before.HeapAlloc
+	}
+
+	return elapsed, MemStats{
+		Bytes:          after.TotalAlloc - before.TotalAlloc,
+		Mallocs:        after.Mallocs - before.Mallocs,
+		NumGC:          after.NumGC - before.NumGC,
+		PauseTotal:     time.Duration(after.PauseTotalNs - before.PauseTotalNs),
+		PeakHeapGrowth: growth,
+		SamplePause:    s.pause,
+	}
+}
+
+// formatBytes formats n using binary prefixes, e.g. 1.5 MiB.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,54 @@
+package solver
+
+import (
+	"testing"
+	"time"
+)
+
+var sink []byte
+
+func TestMeasure(t *testing.T) {
+	const size = 8 << 20
+	elapsed, m := measure(func() {
+		sink = make([]byte, size)
+		time.Sleep(5 * peakHeapInterval)
+	})
+	defer func() { sink = nil }()
+
+	if m.Bytes < size || m.Mallocs < 1 {
+		t.Errorf("measured %d bytes in %d allocs, want at least %d bytes in 1 alloc", m.Bytes, m.Mallocs, size)
+	}
+	if m.PeakHeapGrowth < size*9/10 || m.PeakHeapGrowth > 2*size {
+		t.Errorf("peak heap growth = %d, want about %d", m.PeakHeapGrowth, size)
+	}
+	if m.SamplePause <= 0 {
+		t.Errorf("sample pause = %s, want the time spent sampling", m.SamplePause)
+	}
+	if total := elapsed + m.SamplePause; total < 5*peakHeapInterval {
+		t.Errorf("elapsed %s + sample pause %s < %s", elapsed, m.SamplePause, 5*peakHeapInterval)
+	}
+
+	// A solver which finishes before the first sample is not paused.
+	if _, m := measure(func() {}); m.SamplePause != 0 || m.Bytes != 0 || m.PeakHeapGrowth != 0 {
+		t.Errorf("measure of a no-op = %+v, want no allocations and no sampling", m)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 << 20, "5.0 MiB"},
+		{3 << 30, "3.0 GiB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -14,26 +14,55 @@ var (
 
 type Plugin = plugin.Plugin
 
-func FromPlugin(p *Plugin, d aoc.Day, pt aoc.Part) (Func, error) {
-	v, err := p.Lookup("Day" + d.String() + pt.String())
+// FromPlugin looks up the solver of a part which is either a Func or a
+// LogFunc.
+func FromPlugin(p *Plugin, d aoc.Day, pt aoc.Part) (LogFunc, error) {
+	return lookup(p, "Day"+d.String()+pt.String())
+}
+
+func lookup(p *Plugin, name string) (LogFunc, error) {
+	v, err := p.Lookup(name)
 	if err != nil {
 		return nil, fmt.Errorf("no solver found: %w", err)
 	}
-	solver, ok := v.(Func)
-	if !ok {
-		return nil, fmt.Errorf("incorrect func for Day%s%s got `%T`, expected `%T`: %w",
-			d, pt, v, solver, ErrSolverInvalidSignature)
+	switch solver := v.(type) {
+	case Func:
+		return Adapt(solver), nil
+	case LogFunc:
+		return solver, nil
 	}
-	return solver, nil
+	return nil, fmt.Errorf("incorrect func for %s got `%T`, expected `%T` or `%T`: %w",
+		name, v, Func(nil), LogFunc(nil), ErrSolverInvalidSignature)
+}
+
+// FromPluginBoth looks up the solvers of both parts of d. The second solver is
+// not looked up for the final day of an event which only has a single part.
+func FromPluginBoth(p *Plugin, d aoc.Date) (LogFunc, LogFunc, error) {
+	return both(p, d, FromPlugin)
 }
 
-func FromPluginBoth(p *Plugin, d aoc.Day) (Func, Func, error) {
-	a, err := FromPlugin(p, d, aoc.Part1)
+// FromDayPluginBoth is FromPluginBoth for the plugin of a single day of which
+// the solvers are named PartA and PartB, or after the day.
+func FromDayPluginBoth(p *Plugin, d aoc.Date) (LogFunc, LogFunc, error) {
+	return both(p, d, func(p *Plugin, day aoc.Day, pt aoc.Part) (LogFunc, error) {
+		f, err := lookup(p, "Part"+pt.String())
+		if err == nil || errors.Is(err, ErrSolverInvalidSignature) {
+			return f, err
+		}
+		return FromPlugin(p, day, pt)
+	})
+}
+
+func both(p *Plugin, d aoc.Date, find func(*Plugin, aoc.Day, aoc.Part) (LogFunc, error)) (LogFunc, LogFunc, error) {
+	a, err := find(p, d.Day, aoc.Part1)
 	if err != nil {
 		return nil, nil, err
 	}
+	if aoc.Event(d.Year).SinglePart(d.Day) {
+		return a, nil, nil
+	}
 
-	b, err := FromPlugin(p, d, aoc.Part2)
+	b, err := find(p, d.Day, aoc.Part2)
 	if errors.Is(err, ErrSolverInvalidSignature) {
 		return a, nil, err
 	}
@@ -0,0 +1,53 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/logger"
+)
+
+func TestBoth(t *testing.T) {
+	f := func(string, *logger.Logger) (interface{}, error) { return nil, nil }
+
+	tests := []struct {
+		date    aoc.Date
+		lookups []aoc.Part
+	}{
+		{aoc.Date{Year: 2020, Day: 24}, []aoc.Part{aoc.Part1, aoc.Part2}},
+		{aoc.Date{Year: 2020, Day: 25}, []aoc.Part{aoc.Part1}},
+		{aoc.Date{Year: 2025, Day: 11}, []aoc.Part{aoc.Part1, aoc.Part2}},
+		{aoc.Date{Year: 2025, Day: 12}, []aoc.Part{aoc.Part1}},
+	}
+	for _, tt := range tests {
+		var looked []aoc.Part
+		find := func(_ *Plugin, d aoc.Day, pt aoc.Part) (LogFunc, error) {
+			looked = append(looked, pt)
+			if d != tt.date.Day {
+				return nil, fmt.Errorf("looked up day %s", d)
+			}
+			if pt == aoc.Part2 {
+				// An invalid part B must only fail days which have one.
+				return nil, ErrSolverInvalidSignature
+			}
+			return f, nil
+		}
+
+		a, b, err := both(nil, tt.date, find)
+		single := len(tt.lookups) == 1
+		if a == nil || b != nil {
+			t.Errorf("%s: got a=%v b=%v", tt.date, a != nil, b != nil)
+		}
+		if single && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.date, err)
+		}
+		if !single && !errors.Is(err, ErrSolverInvalidSignature) {
+			t.Errorf("%s: err = %v, want ErrSolverInvalidSignature", tt.date, err)
+		}
+		if fmt.Sprint(looked) != fmt.Sprint(tt.lookups) {
+			t.Errorf("%s: looked up parts %v, want %v", tt.date, looked, tt.lookups)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package solver
+
+import (
+	"encoding"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aod/elver/ocr"
+)
+
+// shades draws the pixels of an image from dark to light.
+var shades = []rune(" ░▒▓█")
+
+// render returns the answer as it is shown to the user. Grids are drawn as
+// blocks followed by the letters they draw, if any, and maps are drawn as a
+// line per key.
+func render(v Output) string {
+	if s, ok := textOf(v); ok {
+		v = s
+	}
+	if rs, ok := rows(v); ok {
+		g := grid(rs)
+		if text, ok := ocr.Parse(rs); ok {
+			return g + "\n" + text
+		}
+		return g
+	}
+	if s, ok := mapLines(v, render); ok {
+		return s
+	}
+	return text(v)
+}
+
+// text returns the answer in its canonical text form, which is used for
+// comparing answers. Grids drawing letters are read as those letters and
+// numbers of any type are formatted the same.
+func text(v Output) string {
+	if s, ok := textOf(v); ok {
+		v = s
+	}
+	if rs, ok := rows(v); ok {
+		if text, ok := ocr.Parse(rs); ok {
+			return text
+		}
+		return grid(rs)
+	}
+	if s, ok := number(v); ok {
+		return s
+	}
+	if s, ok := mapLines(v, text); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// textOf returns the text of v when it implements encoding.TextMarshaler or
+// fmt.Stringer.
+func textOf(v Output) (string, bool) {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return "", false
+	}
+	switch v := v.(type) {
+	case encoding.TextMarshaler:
+		b, err := v.MarshalText()
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	case fmt.Stringer:
+		return v.String(), true
+	}
+	return "", false
+}
+
+// rows returns v as rows of runes when it is grid-like: [][]rune, [][]byte,
+// [][]bool, []string, a string with multiple lines of only pixels, or an
+// image.Image. Set booleans are drawn as a full block.
+func rows(v Output) ([][]rune, bool) {
+	switch v := v.(type) {
+	case nil:
+		return nil, false
+	case string:
+		if _, ok := ocr.Grid(v); !ok {
+			return nil, false
+		}
+		lines := strings.Split(strings.Trim(v, "\n"), "\n")
+		return rows(lines)
+	case image.Image:
+		return imageRows(v), true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Len() == 0 {
+		return nil, false
+	}
+	rs := make([][]rune, rv.Len())
+	switch elem := rv.Type().Elem(); {
+	case elem.Kind() == reflect.String:
+		for i := range rs {
+			rs[i] = []rune(rv.Index(i).String())
+		}
+	case elem.Kind() == reflect.Slice:
+		// The kind is checked up front as a grid of empty rows has no cells
+		// to check, e.g. [][]int{{}} is not a grid.
+		kind := elem.Elem().Kind()
+		if kind != reflect.Bool && kind != reflect.Uint8 && kind != reflect.Int32 {
+			return nil, false
+		}
+		for i := range rs {
+			row := rv.Index(i)
+			rs[i] = make([]rune, row.Len())
+			for j := range rs[i] {
+				switch c := row.Index(j); kind {
+				case reflect.Bool:
+					rs[i][j] = ' '
+					if c.Bool() {
+						rs[i][j] = '█'
+					}
+				case reflect.Uint8:
+					rs[i][j] = rune(c.Uint())
+				case reflect.Int32:
+					rs[i][j] = rune(c.Int())
+				}
+			}
+		}
+	default:
+		return nil, false
+	}
+	return rs, true
+}
+
+func imageRows(img image.Image) [][]rune {
+	b := img.Bounds()
+	rs := make([][]rune, b.Dy())
+	for y := range rs {
+		rs[y] = make([]rune, b.Dx())
+		for x := range rs[y] {
+			// Colors are alpha-premultiplied so transparent pixels are dark.
+			gray := color.Gray16Model.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray16)
+			rs[y][x] = shades[int(gray.Y)*(len(shades)-1)/0xffff]
+		}
+	}
+	return rs
+}
+
+func grid(rs [][]rune) string {
+	lines := make([]string, len(rs))
+	for i, r := range rs {
+		lines[i] = string(r)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// number formats numbers of any type the same, e.g. int64(5), uint8(5) and
+// float64(5) are all 5.
+func number(v Output) (string, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f == math.Trunc(f) && math.Abs(f) < 1<<63 {
+			return strconv.FormatInt(int64(f), 10), true
+		}
+		return strconv.FormatFloat(f, 'f', -1, rv.Type().Bits()), true
+	}
+	return "", false
+}
+
+// mapLines formats a map as a "key: value" line per key sorted by key.
+// Numeric keys are sorted by their value.
+func mapLines(v Output, format func(Output) string) (string, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return "", false
+	}
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		}
+		return text(a.Interface()) < text(b.Interface())
+	})
+
+	lines := make([]string, len(keys))
+	for i, k := range keys {
+		lines[i] = text(k.Interface()) + ": " + format(rv.MapIndex(k).Interface())
+	}
+	return strings.Join(lines, "\n"), true
+}
@@ -0,0 +1,55 @@
+package solver
+
+import (
+	"image"
+	"image/color"
+	"math/big"
+	"testing"
+
+	"github.com/aod/elver/aoc"
+)
+
+type cells [][]bool
+
+func TestRender(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 3, 2))
+	img.SetGray(0, 0, color.Gray{Y: 0xff})
+	img.SetGray(1, 1, color.Gray{Y: 0x80})
+
+	var nilInt *big.Int
+	tests := []struct {
+		name       string
+		v          Output
+		text, show string
+	}{
+		{"int", 5, "5", "5"},
+		{"int64", int64(5), "5", "5"},
+		{"uint8", uint8(5), "5", "5"},
+		{"float", 5.0, "5", "5"},
+		{"big float", 1e21, "1000000000000000000000", "1000000000000000000000"},
+		{"fraction", float32(0.5), "0.5", "0.5"},
+		{"stringer", big.NewInt(7), "7", "7"},
+		{"nil stringer", nilInt, "<nil>", "<nil>"},
+		{"text marshaler", aoc.Date{Year: 2020, Day: 5}, "2020/5", "2020/5"},
+		{"bools", cells{{true, false}, {false, true}}, "█ \n █", "█ \n █"},
+		{"bytes", [][]byte{[]byte("ab"), []byte("cd")}, "ab\ncd", "ab\ncd"},
+		{"runes", [][]rune{[]rune("ab"), []rune("cd")}, "ab\ncd", "ab\ncd"},
+		{"lines", []string{"ab", "cd"}, "ab\ncd", "ab\ncd"},
+		{"empty int rows", [][]int{{}}, "[[]]", "[[]]"},
+		{"empty string rows", [][]string{{}, {}}, "[[] []]", "[[] []]"},
+		{"empty rune rows", [][]rune{{}, {}}, "\n", "\n"},
+		{"image", img, "█  \n ▒ ", "█  \n ▒ "},
+		{"letters", "#..#\n#..#\n####\n#..#\n#..#\n#..#\n", "H", "#..#\n#..#\n####\n#..#\n#..#\n#..#\nH"},
+		{"map", map[int]bool{10: true, 2: false}, "2: false\n10: true", "2: false\n10: true"},
+		{"text", "hello", "hello", "hello"},
+		{"nil", nil, "<nil>", "<nil>"},
+	}
+	for _, tt := range tests {
+		if got := text(tt.v); got != tt.text {
+			t.Errorf("%s: text() = %q, want %q", tt.name, got, tt.text)
+		}
+		if got := render(tt.v); got != tt.show {
+			t.Errorf("%s: render() = %q, want %q", tt.name, got, tt.show)
+		}
+	}
+}
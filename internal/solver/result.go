@@ -2,6 +2,7 @@ package solver
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,6 +20,10 @@ type ResultAttribute struct {
 	ResultKind
 	B *testing.BenchmarkResult
 	T *time.Duration
+	M *MemStats
+	// Timing is set when the solver was timed over multiple runs, T is then
+	// the median.
+	Timing *Timing
 }
 
 func (r ResultAttribute) String() string {
@@ -27,28 +32,70 @@ func (r ResultAttribute) String() string {
 		return fmt.Sprintf("(N=%d, %d ns/op, %d bytes/op, %d allocs/op):\n",
 			r.B.N, r.B.NsPerOp(), r.B.AllocedBytesPerOp(), r.B.AllocsPerOp())
 	case TimeResult:
+		if r.Timing != nil && r.M != nil {
+			return fmt.Sprintf("(%s, %s):\n", r.Timing, r.M)
+		}
+		if r.M != nil {
+			return fmt.Sprintf("(%s, %s):\n", r.T, r.M)
+		}
 		return fmt.Sprintf("(%s):\n", r.T)
 	}
 	return ""
 }
 
+// Duration returns the time it took to solve once, or 0 when unknown.
+func (r ResultAttribute) Duration() time.Duration {
+	switch {
+	case r.ResultKind == BenchmarkResult && r.B != nil:
+		return time.Duration(r.B.NsPerOp())
+	case r.ResultKind == TimeResult && r.T != nil:
+		return *r.T
+	}
+	return 0
+}
+
 type Result struct {
 	aoc.DatePart
-	Attr   ResultAttribute
-	Err    error
-	Answer Output
+	// Variant is the name of the variant which gave the result, if any.
+	Variant string
+	Attr    ResultAttribute
+	Err     error
+	Answer  Output
+	// Log is the debug output the solver wrote to its logger.
+	Log string
+	// Output is what the solver wrote to the standard output and error when
+	// it was captured.
+	Output string
+	// OutputErr is why the output could not be captured, the solver then ran
+	// with its output let through.
+	OutputErr error
 }
 
 func (s Result) String() string {
-	res := fmt.Sprintf("Day %s %s ", s.Day, s.Part)
+	res := fmt.Sprintf("Day %s %s ", s.Day, partName(s.Part, s.Variant))
 	res += s.Attr.String()
 	res += s.answer()
 	return res
 }
 
+// Text returns the answer as text which is used for comparing it against
+// known correct answers. Answers drawing letters are decoded to those letters
+// and numbers of any type are formatted the same.
+func (s Result) Text() string {
+	return strings.TrimSpace(text(s.Answer))
+}
+
 func (s Result) answer() string {
 	if err := s.Err; err != nil {
 		return fmt.Sprintf("[ERROR] %s\n", err)
 	}
-	return fmt.Sprintf("%v", s.Answer)
+	return render(s.Answer)
+}
+
+// partName returns the name of a part followed by the name of its variant.
+func partName(p aoc.Part, variant string) string {
+	if variant == "" {
+		return p.String()
+	}
+	return p.String() + "_" + variant
 }
@@ -0,0 +1,65 @@
+package solver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Timing summarizes the durations of a solver which ran multiple times.
+type Timing struct {
+	Runs   int
+	Min    time.Duration
+	Median time.Duration
+	Max    time.Duration
+}
+
+func (t Timing) String() string {
+	return fmt.Sprintf("median %s, min %s, max %s of %d runs", t.Median, t.Min, t.Max, t.Runs)
+}
+
+// newTiming summarizes ds, it returns the index of the run closest to the
+// median as well.
+func newTiming(ds []time.Duration) (Timing, int) {
+	idx := make([]int, len(ds))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return ds[idx[i]] < ds[idx[j]] })
+
+	mid := (len(idx) - 1) / 2
+	t := Timing{
+		Runs:   len(ds),
+		Min:    ds[idx[0]],
+		Median: ds[idx[mid]],
+		Max:    ds[idx[len(idx)-1]],
+	}
+	if len(idx)%2 == 0 {
+		t.Median = (ds[idx[mid]] + ds[idx[mid+1]]) / 2
+	}
+	return t, idx[mid]
+}
+
+// distinct returns the distinct answers in the order they first appeared.
+func distinct(answers []string) []string {
+	seen := make(map[string]bool)
+	var ds []string
+	for _, a := range answers {
+		if !seen[a] {
+			seen[a] = true
+			ds = append(ds, a)
+		}
+	}
+	return ds
+}
+
+// inconsistentError is returned when a solver does not give the same answer
+// every time it runs on the same input.
+func inconsistentError(answers []string) error {
+	quoted := make([]string, len(answers))
+	for i, a := range answers {
+		quoted[i] = fmt.Sprintf("%q", a)
+	}
+	return fmt.Errorf("inconsistent answers across runs: %s", strings.Join(quoted, ", "))
+}
@@ -0,0 +1,84 @@
+package solver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aod/elver/logger"
+)
+
+func TestNewTiming(t *testing.T) {
+	ds := []time.Duration{5, 1, 4, 2}
+	got, i := newTiming(ds)
+	want := Timing{Runs: 4, Min: 1, Median: 3, Max: 5}
+	if got != want {
+		t.Errorf("newTiming(%v) = %+v, want %+v", ds, got, want)
+	}
+	if ds[i] != 2 {
+		t.Errorf("newTiming(%v) median run = %v, want 2", ds, ds[i])
+	}
+}
+
+func TestResultRuns(t *testing.T) {
+	calls := 0
+	s := Solver{
+		Solver: Adapt(func(string) (Output, error) {
+			calls++
+			return calls % 2, nil
+		}),
+		Warmup: 1,
+		Runs:   3,
+	}
+	r := s.Result("", TimeResult)
+	if calls != 4 {
+		t.Errorf("solver ran %d times, want 4", calls)
+	}
+	if r.Attr.Timing == nil || r.Attr.Timing.Runs != 3 {
+		t.Errorf("timing = %+v, want 3 runs", r.Attr.Timing)
+	}
+	if r.Err == nil || !strings.Contains(r.Err.Error(), `"0", "1"`) {
+		t.Errorf("err = %v, want the inconsistent answers", r.Err)
+	}
+}
+
+func TestResultLog(t *testing.T) {
+	calls := 0
+	s := Solver{
+		Solver: func(_ string, log *logger.Logger) (Output, error) {
+			calls++
+			log.Printf("run %d", calls)
+			return 1, nil
+		},
+		Warmup: 1,
+		Runs:   2,
+	}
+	if got := s.Result("", TimeResult).Log; got != "run 1\n" {
+		t.Errorf("Log = %q, want only the output of the first run", got)
+	}
+}
+
+func TestResultWarmupError(t *testing.T) {
+	s := Solver{
+		Solver: Adapt(func(string) (Output, error) {
+			time.Sleep(time.Millisecond)
+			return nil, errors.New("failed")
+		}),
+		Warmup: 1,
+		Runs:   3,
+	}
+	r := s.Result("", TimeResult)
+	if r.Err == nil || r.Err.Error() != "failed" {
+		t.Errorf("err = %v, want failed", r.Err)
+	}
+	if d := r.Attr.Duration(); d < time.Millisecond {
+		t.Errorf("Duration() = %s, want the duration of the failed warmup run", d)
+	}
+	if got := r.String(); strings.Contains(got, "nil") {
+		t.Errorf("String() = %q", got)
+	}
+	if d := (ResultAttribute{ResultKind: TimeResult}).Duration(); d != 0 {
+		t.Errorf("Duration() without a time = %s, want 0", d)
+	}
+}
@@ -1,48 +1,155 @@
 package solver
 
 import (
-	"os"
 	"testing"
 	"time"
 
-	"github.com/aod/elver/internal/util"
+	"github.com/aod/elver/internal/capture"
 
 	"github.com/aod/elver/aoc"
+	"github.com/aod/elver/logger"
 )
 
 type (
 	Input  = string
 	Output = interface{}
 	Func   = func(Input) (Output, error)
+	// LogFunc is a solver which writes debug output to the given logger.
+	LogFunc = func(Input, *logger.Logger) (Output, error)
 )
 
+// Adapt returns f as a LogFunc which ignores the logger.
+func Adapt(f Func) LogFunc {
+	if f == nil {
+		return nil
+	}
+	return func(in Input, _ *logger.Logger) (Output, error) {
+		return f(in)
+	}
+}
+
 type Solver struct {
 	aoc.DatePart
-	Solver Func
+	Solver LogFunc
+	// Variant is the name of the variant of the solver of the part, if any.
+	Variant string
+
+	// Warmup is the number of untimed runs before timing a TimeResult.
+	Warmup int
+	// Runs is the number of timed runs of a TimeResult, at least one.
+	Runs int
+	// Capture captures what the solver writes to the standard output and
+	// error instead of letting it through. While benchmarking it is always
+	// discarded.
+	Capture bool
 }
 
 func (s Solver) Result(input string, rk ResultKind) Result {
-	r := Result{DatePart: s.DatePart, Attr: ResultAttribute{ResultKind: rk}}
+	r := Result{DatePart: s.DatePart, Variant: s.Variant, Attr: ResultAttribute{ResultKind: rk}}
 	switch rk {
 	case BenchmarkResult:
-		defer util.RedirectNull(&os.Stdout, &os.Stderr)()
-		b := testing.Benchmark(func(b *testing.B) {
-			for i := 0; i < b.N; i++ {
-				if r.Answer, r.Err = s.Solve(input); r.Err != nil {
-					b.FailNow()
+		var b testing.BenchmarkResult
+		bench := func() {
+			b = testing.Benchmark(func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if r.Answer, r.Err = s.Solve(input); r.Err != nil {
+						b.FailNow()
+					}
 				}
-			}
-		})
+			})
+		}
+		// The output is let through when it can not be discarded.
+		capture.Discard(bench)
 		r.Attr.B = &b
 	case TimeResult:
-		start := time.Now()
-		r.Answer, r.Err = s.Solve(input)
-		elapsed := time.Since(start)
-		r.Attr.T = &elapsed
+		s.time(input, &r)
 	}
 	return r
 }
 
+// time runs the solver Warmup times followed by Runs timed runs. The run
+// closest to the median is reported. Only the debug and captured output of the
+// first run are kept. A warmup run which fails is reported with its duration
+// and without memory statistics.
+func (s Solver) time(input string, r *Result) {
+	first := true
+	run := func(f func(log *logger.Logger)) {
+		if !first {
+			s.quiet(func() { f(logger.Discard) })
+			return
+		}
+		first = false
+
+		log := logger.New()
+		if s.Capture {
+			out, err := capture.Run(func() { f(log) })
+			r.Output, r.OutputErr = string(out), err
+		} else {
+			f(log)
+		}
+		r.Log = log.String()
+	}
+
+	for i := 0; i < s.Warmup; i++ {
+		var elapsed time.Duration
+		run(func(log *logger.Logger) {
+			start := time.Now()
+			r.Answer, r.Err = s.Solver(input, log)
+			elapsed = time.Since(start)
+		})
+		if r.Err != nil {
+			r.Attr.T = &elapsed
+			return
+		}
+	}
+
+	n := s.Runs
+	if n < 1 {
+		n = 1
+	}
+	durations := make([]time.Duration, 0, n)
+	mems := make([]MemStats, 0, n)
+	answers := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		var elapsed time.Duration
+		var mem MemStats
+		run(func(log *logger.Logger) {
+			elapsed, mem = measure(func() {
+				r.Answer, r.Err = s.Solver(input, log)
+			})
+		})
+		durations = append(durations, elapsed)
+		mems = append(mems, mem)
+		if r.Err != nil {
+			break
+		}
+		answers = append(answers, r.Text())
+	}
+
+	t, i := newTiming(durations)
+	r.Attr.T = &t.Median
+	r.Attr.M = &mems[i]
+	if t.Runs > 1 {
+		r.Attr.Timing = &t
+	}
+	if r.Err == nil {
+		if ds := distinct(answers); len(ds) > 1 {
+			r.Err = inconsistentError(ds)
+		}
+	}
+}
+
+// quiet runs f discarding what it writes to the standard output and error
+// when the output of the solver is captured, if that is possible.
+func (s Solver) quiet(f func()) {
+	if !s.Capture {
+		f()
+		return
+	}
+	capture.Discard(f)
+}
+
+// Solve runs the solver discarding its debug output.
 func (s Solver) Solve(in Input) (Output, error) {
-	return s.Solver(in)
+	return s.Solver(in, logger.Discard)
 }
@@ -0,0 +1,22 @@
+package solver
+
+// Variant is an alternative solver of a part, e.g. Day7A_naive is the variant
+// naive of part A of day 7.
+type Variant struct {
+	Name   string
+	Solver LogFunc
+}
+
+// VariantsFromPlugin looks up the declared variants of a part as returned by
+// Discovery.Variants.
+func VariantsFromPlugin(p *Plugin, declared []Declared) ([]Variant, error) {
+	var variants []Variant
+	for _, d := range declared {
+		f, err := lookup(p, d.Name)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, Variant{Name: d.Variant, Solver: f})
+	}
+	return variants, nil
+}
@@ -0,0 +1,29 @@
+package term
+
+import (
+	"os"
+	"strings"
+)
+
+// readLine reads from f up to and excluding the first newline. It reads byte
+// by byte so that nothing after the line is consumed.
+func readLine(f *os.File) (string, error) {
+	var sb strings.Builder
+	b := make([]byte, 1)
+	for {
+		n, err := f.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			sb.WriteByte(b[0])
+		}
+		if err != nil {
+			if sb.Len() > 0 {
+				break
+			}
+			return "", err
+		}
+	}
+	return strings.TrimRight(sb.String(), "\r"), nil
+}
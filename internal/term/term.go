@@ -0,0 +1,79 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+// Package term provides minimal terminal handling without third party
+// dependencies.
+package term
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func getTermios(fd int) (*syscall.Termios, error) {
+	var t syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlGetTermios, uintptr(unsafe.Pointer(&t)))
+	if errno != 0 {
+		return nil, errno
+	}
+	return &t, nil
+}
+
+func setTermios(fd int, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), ioctlSetTermios, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// IsTerminal reports whether f refers to a terminal.
+func IsTerminal(f *os.File) bool {
+	_, err := getTermios(int(f.Fd()))
+	return err == nil
+}
+
+// ReadPassword reads a line from f without echoing it. The echo is only
+// disabled when f is a terminal.
+func ReadPassword(f *os.File) (string, error) {
+	fd := int(f.Fd())
+	old, err := getTermios(fd)
+	if err != nil {
+		return readLine(f)
+	}
+
+	t := *old
+	t.Lflag &^= syscall.ECHO
+	t.Lflag |= syscall.ICANON | syscall.ISIG
+	if err := setTermios(fd, &t); err != nil {
+		return "", err
+	}
+	defer setTermios(fd, old)
+
+	return readLine(f)
+}
+
+// MakeRaw puts the terminal f in raw mode so every key press can be read as it
+// happens without being echoed. The returned function restores the previous
+// mode.
+func MakeRaw(f *os.File) (func() error, error) {
+	fd := int(f.Fd())
+	old, err := getTermios(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	t := *old
+	t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cflag &^= syscall.CSIZE | syscall.PARENB
+	t.Cflag |= syscall.CS8
+	t.Cc[syscall.VMIN] = 1
+	t.Cc[syscall.VTIME] = 0
+	if err := setTermios(fd, &t); err != nil {
+		return nil, err
+	}
+	return func() error { return setTermios(fd, old) }, nil
+}
@@ -0,0 +1,11 @@
+//go:build darwin || freebsd
+// +build darwin freebsd
+
+package term
+
+import "syscall"
+
+const (
+	ioctlGetTermios = syscall.TIOCGETA
+	ioctlSetTermios = syscall.TIOCSETA
+)
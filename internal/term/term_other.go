@@ -0,0 +1,28 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+// Package term provides minimal terminal handling without third party
+// dependencies.
+package term
+
+import (
+	"errors"
+	"os"
+)
+
+// IsTerminal reports whether f refers to a terminal. It always returns false
+// on unsupported platforms.
+func IsTerminal(f *os.File) bool {
+	return false
+}
+
+// ReadPassword reads a line from f. The input is echoed on unsupported
+// platforms.
+func ReadPassword(f *os.File) (string, error) {
+	return readLine(f)
+}
+
+// MakeRaw is not supported on this platform and always returns an error.
+func MakeRaw(f *os.File) (func() error, error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
@@ -0,0 +1,87 @@
+// Package logger lets solvers write debug output which elver captures per
+// part and shows after the answer, instead of mixing it with its own output.
+//
+// A solver receives a Logger by accepting it as its second argument:
+//
+//	func Day1A(input string, log *logger.Logger) (interface{}, error)
+//
+// While benchmarking the Logger discards everything. Use Enabled to skip
+// expensive work which only produces debug output.
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// Logger collects debug output. It is safe for concurrent use. A nil *Logger
+// discards everything.
+type Logger struct {
+	mu  sync.Mutex
+	buf *bytes.Buffer
+}
+
+// Discard is a Logger which discards everything written to it.
+var Discard = &Logger{}
+
+// New returns a Logger which keeps everything written to it.
+func New() *Logger {
+	return &Logger{buf: new(bytes.Buffer)}
+}
+
+// Enabled reports whether output written to l is kept.
+func (l *Logger) Enabled() bool {
+	return l != nil && l.buf != nil
+}
+
+// Print writes the operands to l like fmt.Print followed by a newline if
+// there is none.
+func (l *Logger) Print(v ...interface{}) {
+	if l.Enabled() {
+		l.line(fmt.Sprint(v...))
+	}
+}
+
+// Printf writes to l like fmt.Printf followed by a newline if there is none.
+func (l *Logger) Printf(format string, v ...interface{}) {
+	if l.Enabled() {
+		l.line(fmt.Sprintf(format, v...))
+	}
+}
+
+// Println writes the operands to l like fmt.Println.
+func (l *Logger) Println(v ...interface{}) {
+	if l.Enabled() {
+		l.line(fmt.Sprintln(v...))
+	}
+}
+
+// Write implements io.Writer so l can be used with e.g. fmt.Fprintf.
+func (l *Logger) Write(p []byte) (int, error) {
+	if !l.Enabled() {
+		return len(p), nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.Write(p)
+}
+
+// String returns everything written to l.
+func (l *Logger) String() string {
+	if !l.Enabled() {
+		return ""
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.String()
+}
+
+func (l *Logger) line(s string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf.WriteString(s)
+	if len(s) == 0 || s[len(s)-1] != '\n' {
+		l.buf.WriteByte('\n')
+	}
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	l := New()
+	l.Print("a", 1)
+	l.Printf("b %d\n", 2)
+	l.Println("c", 3)
+	fmt.Fprint(l, "d")
+	if got, want := l.String(), "a1\nb 2\nc 3\nd"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	var nilLogger *Logger
+	for _, l := range []*Logger{Discard, nilLogger} {
+		if l.Enabled() {
+			t.Errorf("%#v.Enabled() = true, want false", l)
+		}
+		l.Printf("%d", 1)
+		if n, err := l.Write([]byte("ab")); n != 2 || err != nil {
+			t.Errorf("Write() = %d, %v, want 2, nil", n, err)
+		}
+		if got := l.String(); got != "" {
+			t.Errorf("String() = %q, want empty", got)
+		}
+	}
+}
@@ -10,14 +10,23 @@ These must reside in an Advent of Code folder under the main package.
 
 A solution for a day in an Advent of Code year is represented by 2 solvers
 for part A and B.
-All solvers are functions which satisfy the same signature where interface{}
-is the output:
+All solvers are functions which satisfy one of the following signatures where
+interface{} is the output:
 
 	func (input string) (interface{}, error)
+	func (input string, log *logger.Logger) (interface{}, error)
+
+The second one receives a logger from github.com/aod/elver/logger of which the
+output is shown after the answer.
 
 A solver must be exported and it's name satisfy the following regex:
 
-	(Day)([1-9]|1[0-9]|2[0-5])(A|B)
+	Day([1-9][0-9]?)(A|B)(_\w+)?
+
+Since 2025 an Advent of Code lasts 12 days instead of 25.
+The final day only has a single puzzle, part A.
+A suffix names a variant of a solver, e.g. Day7A_naive, which runs after the
+solver of its part and must give the same answer.
 
 E.g.:
 
@@ -25,6 +34,11 @@ E.g.:
 	    return 42, nil
 	}
 
+	func Day7A_naive(input string, log *logger.Logger) (interface{}, error) {
+	    log.Printf("trying every combination")
+	    return 42, nil
+	}
+
 Solvers are workspaced by the Advent of Code year which is also used as the
 folder name.
 
@@ -43,9 +57,9 @@ Running Elver in the root directory will output something like the following:
 
 	$ elver
 	AOC 2015
-	Day 1 A (312ns):
+	Day 1 A (1.036µs, 0 B in 0 allocs, 0 GCs, peak heap growth 0 B):
 	42
-	Day 1 B (956ns):
+	Day 1 B (9.079µs, 16 B in 1 allocs, 0 GCs, peak heap growth 16 B):
 	[ERROR] Not implemented
 */
 package main
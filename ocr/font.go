@@ -0,0 +1,52 @@
+package ocr
+
+import "strings"
+
+// small is the 6 pixels high font used by most puzzles. Letters are separated
+// by blank columns.
+const small = `
+.##..###...##..####.####..##..#..#.###...##.#..#.#.....##..###..###...###.#..#.#...#.####
+#..#.#..#.#..#.#....#....#..#.#..#..#.....#.#.#..#....#..#.#..#.#..#.#....#..#.#...#....#
+#..#.###..#....###..###..#....####..#.....#.##...#....#..#.#..#.#..#.#....#..#..#.#....#.
+####.#..#.#....#....#....#.##.#..#..#.....#.#.#..#....#..#.###..###...##..#..#...#....#..
+#..#.#..#.#..#.#....#....#..#.#..#..#..#..#.#.#..#....#..#.#....#.#.....#.#..#...#...#...
+#..#.###...##..####.#.....###.#..#.###..##..#..#.####..##..#....#..#.###...##....#...####
+`
+
+const smallLetters = "ABCEFGHIJKLOPRSUYZ"
+
+// large is the 10 pixels high font.
+const large = `
+..##...#####...####..######.######..####..#....#....###.#....#.#......#....#.#####..#####..#....#.######
+.#..#..#....#.#....#.#......#......#....#.#....#.....#..#...#..#......##...#.#....#.#....#.#....#......#
+#....#.#....#.#......#......#......#......#....#.....#..#..#...#......##...#.#....#.#....#..#..#.......#
+#....#.#....#.#......#......#......#......#....#.....#..#.#....#......#.#..#.#....#.#....#..#..#......#.
+#....#.#####..#......#####..#####..#......######.....#..##.....#......#.#..#.#####..#####....##......#..
+######.#....#.#......#......#......#..###.#....#.....#..##.....#......#..#.#.#......#..#.....##.....#...
+#....#.#....#.#......#......#......#....#.#....#.....#..#.#....#......#..#.#.#......#...#...#..#...#....
+#....#.#....#.#......#......#......#....#.#....#.#...#..#..#...#......#...##.#......#...#...#..#..#.....
+#....#.#....#.#....#.#......#......#...##.#....#.#...#..#...#..#......#...##.#......#....#.#....#.#.....
+#....#.#####...####..######.#.......###.#.#....#..###...#....#.######.#....#.#......#....#.#....#.######
+`
+
+const largeLetters = "ABCEFGHJKLNPRXZ"
+
+// fonts maps the height of a font to its letters keyed by their pixels.
+var fonts = map[int]map[string]rune{
+	6:  parseFont(small, smallLetters),
+	10: parseFont(large, largeLetters),
+}
+
+func parseFont(font, letters string) map[string]rune {
+	g, _ := Grid(strings.TrimSpace(font))
+	glyphs := split(g)
+	if len(glyphs) != len(letters) {
+		panic("ocr: font does not match its letters")
+	}
+
+	m := make(map[string]rune, len(glyphs))
+	for i, r := range letters {
+		m[key(glyphs[i])] = r
+	}
+	return m
+}
@@ -0,0 +1,180 @@
+// Package ocr recognizes answers of Advent of Code which are capital letters
+// drawn as a grid of pixels, e.g.:
+//
+//	#..#.####
+//	#..#.#...
+//	####.###.
+//	#..#.#...
+//	#..#.#...
+//	#..#.####
+//
+// Both the 6 pixels high font used by most puzzles and the 10 pixels high font
+// are recognized.
+package ocr
+
+import (
+	"strings"
+)
+
+// Parse decodes the letters drawn by v which is either a string, []string,
+// [][]bool, [][]rune or [][]byte. It returns false if v is not a grid of
+// known letters.
+func Parse(v interface{}) (string, bool) {
+	g, ok := Grid(v)
+	if !ok {
+		return "", false
+	}
+	return Decode(g)
+}
+
+// Decode decodes the letters drawn by the set pixels of g. Blank rows and
+// columns around the letters are ignored.
+func Decode(g [][]bool) (string, bool) {
+	g = trimRows(g)
+	font, ok := fonts[len(g)]
+	if !ok {
+		return "", false
+	}
+
+	glyphs := split(g)
+	if len(glyphs) == 0 {
+		return "", false
+	}
+	var sb strings.Builder
+	for _, glyph := range glyphs {
+		r, ok := font[key(glyph)]
+		if !ok {
+			return "", false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String(), true
+}
+
+// Grid converts v, which is either a string, []string, [][]bool, [][]rune or
+// [][]byte, to a grid of pixels. A pixel is set when it is a '#' or '█' and
+// unset when it is a '.' or ' '. Any other character makes it not a grid.
+func Grid(v interface{}) ([][]bool, bool) {
+	switch v := v.(type) {
+	case string:
+		lines := strings.Split(strings.Trim(v, "\n"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSuffix(line, "\r")
+		}
+		return Grid(lines)
+	case []string:
+		rows := make([][]rune, len(v))
+		for i, s := range v {
+			rows[i] = []rune(s)
+		}
+		return Grid(rows)
+	case [][]byte:
+		rows := make([][]rune, len(v))
+		for i, b := range v {
+			rows[i] = []rune(string(b))
+		}
+		return Grid(rows)
+	case [][]rune:
+		g := make([][]bool, len(v))
+		for y, row := range v {
+			g[y] = make([]bool, len(row))
+			for x, r := range row {
+				switch r {
+				case '#', '█':
+					g[y][x] = true
+				case '.', ' ':
+				default:
+					return nil, false
+				}
+			}
+		}
+		return g, len(g) > 1
+	case [][]bool:
+		return v, len(v) > 1
+	}
+	return nil, false
+}
+
+// Render draws g using '#' for set and '.' for unset pixels.
+func Render(g [][]bool) string {
+	var sb strings.Builder
+	for y, row := range g {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, p := range row {
+			if p {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+	}
+	return sb.String()
+}
+
+// trimRows removes the blank rows above and below the set pixels of g.
+func trimRows(g [][]bool) [][]bool {
+	blank := func(row []bool) bool {
+		for _, p := range row {
+			if p {
+				return false
+			}
+		}
+		return true
+	}
+	for len(g) > 0 && blank(g[0]) {
+		g = g[1:]
+	}
+	for len(g) > 0 && blank(g[len(g)-1]) {
+		g = g[:len(g)-1]
+	}
+	return g
+}
+
+// split splits g into its letters which are separated by blank columns.
+func split(g [][]bool) [][][]bool {
+	width := 0
+	for _, row := range g {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	set := func(x, y int) bool {
+		return x < len(g[y]) && g[y][x]
+	}
+	blank := func(x int) bool {
+		for y := range g {
+			if set(x, y) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var glyphs [][][]bool
+	for x := 0; x < width; {
+		if blank(x) {
+			x++
+			continue
+		}
+		start := x
+		for x < width && !blank(x) {
+			x++
+		}
+		glyph := make([][]bool, len(g))
+		for y := range g {
+			glyph[y] = make([]bool, x-start)
+			for i := range glyph[y] {
+				glyph[y][i] = set(start+i, y)
+			}
+		}
+		glyphs = append(glyphs, glyph)
+	}
+	return glyphs
+}
+
+// key returns the pixels of a glyph as a string to look it up in a font.
+func key(glyph [][]bool) string {
+	return Render(glyph)
+}
@@ -0,0 +1,71 @@
+package ocr
+
+import (
+	"fmt"
+	"testing"
+)
+
+const hello = `
+#..#.####.#....#.....##.
+#..#.#....#....#....#..#
+####.###..#....#....#..#
+#..#.#....#....#....#..#
+#..#.#....#....#....#..#
+#..#.####.####.####..##.
+`
+
+func TestParse(t *testing.T) {
+	g, _ := Grid(hello)
+	runes := make([][]rune, len(g))
+	bytes := make([][]byte, len(g))
+	lines := make([]string, len(g))
+	for i, row := range g {
+		lines[i] = Render([][]bool{row})
+		runes[i] = []rune(lines[i])
+		bytes[i] = []byte(lines[i])
+	}
+
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+		ok   bool
+	}{
+		{"string", hello, "HELLO", true},
+		{"lines", lines, "HELLO", true},
+		{"bools", g, "HELLO", true},
+		{"runes", runes, "HELLO", true},
+		{"bytes", bytes, "HELLO", true},
+		{"blocks", "█  █\n█  █\n████\n█  █\n█  █\n█  █", "H", true},
+		{"padded", "\n......\n.#..#.\n.#..#.\n.####.\n.#..#.\n.#..#.\n.#..#.\n......\n", "H", true},
+		{"number", 42, "", false},
+		{"text", "HELLO", "", false},
+		{"unknown letter", "#..#\n#..#\n####\n#..#\n#..#\n##.#", "", false},
+		{"wrong height", "#..#\n####\n#..#", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.v)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: Parse() = %q, %t, want %q, %t", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLarge(t *testing.T) {
+	got, ok := Parse(large)
+	if want := largeLetters; got != want || !ok {
+		t.Errorf("Parse(large) = %q, %t, want %q, true", got, ok, want)
+	}
+}
+
+func ExampleParse() {
+	fmt.Println(Parse(`
+.##..###..####
+#..#.#..#.#...
+#..#.###..###.
+####.#..#.#...
+#..#.#..#.#...
+#..#.###..####
+`))
+	// Output: ABE true
+}
@@ -0,0 +1,262 @@
+package viz
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var colorNames = [...]string{
+	Default:       "default",
+	Black:         "black",
+	Red:           "red",
+	Green:         "green",
+	Yellow:        "yellow",
+	Blue:          "blue",
+	Magenta:       "magenta",
+	Cyan:          "cyan",
+	White:         "white",
+	BrightBlack:   "bright-black",
+	BrightRed:     "bright-red",
+	BrightGreen:   "bright-green",
+	BrightYellow:  "bright-yellow",
+	BrightBlue:    "bright-blue",
+	BrightMagenta: "bright-magenta",
+	BrightCyan:    "bright-cyan",
+	BrightWhite:   "bright-white",
+}
+
+func (c Color) String() string {
+	if int(c) < len(colorNames) {
+		return colorNames[c]
+	}
+	return "color(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Palette maps the colors of cells to the colors of an image.
+type Palette struct {
+	// Background is used for blank cells in the default background color and
+	// Foreground for other cells in the default color.
+	Background, Foreground color.RGBA
+	// Colors holds the colors Black up to and including BrightWhite.
+	Colors [16]color.RGBA
+}
+
+// TerminalPalette resembles the default colors of a terminal.
+var TerminalPalette = Palette{
+	Background: rgb(0x000000),
+	Foreground: rgb(0xe5e5e5),
+	Colors: [16]color.RGBA{
+		rgb(0x000000), rgb(0xcd0000), rgb(0x00cd00), rgb(0xcdcd00),
+		rgb(0x0000ee), rgb(0xcd00cd), rgb(0x00cdcd), rgb(0xe5e5e5),
+		rgb(0x7f7f7f), rgb(0xff0000), rgb(0x00ff00), rgb(0xffff00),
+		rgb(0x5c5cff), rgb(0xff00ff), rgb(0x00ffff), rgb(0xffffff),
+	},
+}
+
+func rgb(v uint32) color.RGBA {
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
+
+// Gray returns p with every color converted to gray.
+func (p Palette) Gray() Palette {
+	gray := func(c color.RGBA) color.RGBA {
+		y := color.GrayModel.Convert(c).(color.Gray).Y
+		return color.RGBA{R: y, G: y, B: y, A: 0xff}
+	}
+	p.Background, p.Foreground = gray(p.Background), gray(p.Foreground)
+	for i, c := range p.Colors {
+		p.Colors[i] = gray(c)
+	}
+	return p
+}
+
+// ParsePalette parses a comma separated list starting with an optional base
+// palette, terminal or gray, followed by colors to override as name=rrggbb,
+// e.g. "gray,bg=ffffff,red=ff0000". The names are bg, fg and those of the
+// colors, e.g. bright-red.
+func ParsePalette(s string) (Palette, error) {
+	p := TerminalPalette
+	for i, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if i == 0 && !strings.Contains(field, "=") {
+			switch field {
+			case "", "terminal":
+			case "gray":
+				p = p.Gray()
+			default:
+				return Palette{}, fmt.Errorf("unknown palette %q", field)
+			}
+			continue
+		}
+
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return Palette{}, fmt.Errorf("invalid palette color %q, expected name=rrggbb", field)
+		}
+		v, err := strconv.ParseUint(strings.TrimPrefix(kv[1], "#"), 16, 24)
+		if err != nil || len(strings.TrimPrefix(kv[1], "#")) != 6 {
+			return Palette{}, fmt.Errorf("invalid palette color %q, expected name=rrggbb", field)
+		}
+		c := rgb(uint32(v))
+
+		switch name := kv[0]; name {
+		case "bg":
+			p.Background = c
+		case "fg":
+			p.Foreground = c
+		default:
+			found := false
+			for i := Black; i <= BrightWhite; i++ {
+				if i.String() == name {
+					p.Colors[i-Black] = c
+					found = true
+				}
+			}
+			if !found {
+				return Palette{}, fmt.Errorf("unknown palette color %q", name)
+			}
+		}
+	}
+	return p, nil
+}
+
+// colors returns the colors of p as indexed by index. The zero Palette is the
+// TerminalPalette.
+func (p Palette) colors() color.Palette {
+	if p == (Palette{}) {
+		p = TerminalPalette
+	}
+	cs := color.Palette{p.Background, p.Foreground}
+	for _, c := range p.Colors {
+		cs = append(cs, c)
+	}
+	return cs
+}
+
+// index returns the index of the color a cell is drawn with. The background
+// color of a cell takes precedence over its foreground color.
+func index(c Cell) uint8 {
+	switch {
+	case c.BG > Default && c.BG <= BrightWhite:
+		return uint8(c.BG-Black) + 2
+	case c.R == 0 || c.R == ' ':
+		return 0
+	case c.FG > Default && c.FG <= BrightWhite:
+		return uint8(c.FG-Black) + 2
+	}
+	return 1
+}
+
+// ImageOptions configures how frames are drawn as images. A cell is drawn as
+// a square of a single color since there is no font to draw runes with.
+type ImageOptions struct {
+	// CellSize is the width and height of a cell in pixels, 8 by default.
+	CellSize int
+	// Palette is the TerminalPalette by default.
+	Palette Palette
+	// Delay is the time between frames of a GIF, 100ms by default.
+	Delay time.Duration
+	// Skip is the number of frames to skip after every frame which is kept.
+	// The last frame is always kept.
+	Skip int
+}
+
+func (o ImageOptions) cellSize() int {
+	if o.CellSize <= 0 {
+		return 8
+	}
+	return o.CellSize
+}
+
+// skip returns the frames which are kept.
+func (o ImageOptions) skip(frames []Frame) []Frame {
+	if o.Skip <= 0 {
+		return frames
+	}
+	var kept []Frame
+	for i := 0; i < len(frames); i += o.Skip + 1 {
+		kept = append(kept, frames[i])
+	}
+	if (len(frames)-1)%(o.Skip+1) != 0 {
+		kept = append(kept, frames[len(frames)-1])
+	}
+	return kept
+}
+
+// Image draws f on an image of w by h cells.
+func (o ImageOptions) Image(f Frame, w, h int) *image.Paletted {
+	size := o.cellSize()
+	img := image.NewPaletted(image.Rect(0, 0, w*size, h*size), o.Palette.colors())
+	for y, row := range f.Cells {
+		for x, c := range row {
+			i := index(c)
+			if i == 0 {
+				continue
+			}
+			for py := y * size; py < (y+1)*size; py++ {
+				for px := x * size; px < (x+1)*size; px++ {
+					img.SetColorIndex(px, py, i)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// EncodeGIF writes frames as an animated GIF. Frames smaller than the largest
+// frame are padded with the background color.
+func EncodeGIF(w io.Writer, frames []Frame, o ImageOptions) error {
+	frames = o.skip(frames)
+	if len(frames) == 0 {
+		return errors.New("no frames to encode")
+	}
+
+	delay := o.Delay
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	// The delay of a GIF is in 100ths of a second.
+	hundredths := int(delay / (10 * time.Millisecond))
+	if hundredths < 1 {
+		hundredths = 1
+	}
+
+	width, height := maxSize(frames)
+	g := &gif.GIF{}
+	for _, f := range frames {
+		g.Image = append(g.Image, o.Image(f, width, height))
+		g.Delay = append(g.Delay, hundredths)
+	}
+	return gif.EncodeAll(w, g)
+}
+
+// EncodePNG writes frame f as a PNG.
+func EncodePNG(w io.Writer, f Frame, o ImageOptions) error {
+	width, height := f.Size()
+	if width == 0 || height == 0 {
+		return errors.New("can not encode an empty frame")
+	}
+	return png.Encode(w, o.Image(f, width, height))
+}
+
+func maxSize(frames []Frame) (int, int) {
+	var width, height int
+	for _, f := range frames {
+		w, h := f.Size()
+		if w > width {
+			width = w
+		}
+		if h > height {
+			height = h
+		}
+	}
+	return width, height
+}
@@ -0,0 +1,80 @@
+package viz
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestParsePalette(t *testing.T) {
+	p, err := ParsePalette("gray,bg=ffffff,bright-red=#102030")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Background != rgb(0xffffff) || p.Colors[BrightRed-Black] != rgb(0x102030) {
+		t.Errorf("colors were not overridden: %+v", p)
+	}
+	if c := p.Colors[Red-Black]; c.R != c.G || c.G != c.B {
+		t.Errorf("red = %v, want gray", c)
+	}
+
+	for _, s := range []string{"neon", "red", "red=fff", "purple=ffffff"} {
+		if _, err := ParsePalette(s); err == nil {
+			t.Errorf("ParsePalette(%q) succeeded, want an error", s)
+		}
+	}
+}
+
+func TestSkip(t *testing.T) {
+	frames := make([]Frame, 6)
+	for i := range frames {
+		frames[i].Caption = string(rune('a' + i))
+	}
+	var got string
+	for _, f := range (ImageOptions{Skip: 2}).skip(frames) {
+		got += f.Caption
+	}
+	if got != "adf" {
+		t.Errorf("skip() kept %q, want adf", got)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	small := NewFrame(1, 1)
+	small.Set(0, 0, '#', Default)
+	large := NewFrame(2, 1)
+	large.Cells[0][1].BG = Red
+
+	o := ImageOptions{CellSize: 2, Delay: 50 * time.Millisecond}
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, []Frame{small, large}, o); err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Image) != 2 || g.Delay[0] != 5 {
+		t.Fatalf("got %d frames with delay %d, want 2 and 5", len(g.Image), g.Delay[0])
+	}
+	if b := g.Image[0].Bounds(); b.Dx() != 4 || b.Dy() != 2 {
+		t.Errorf("gif is %dx%d, want 4x2", b.Dx(), b.Dy())
+	}
+
+	buf.Reset()
+	if err := EncodePNG(&buf, large, o); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x, want := range []color.RGBA{TerminalPalette.Background, TerminalPalette.Colors[Red-Black]} {
+		if got := color.RGBAModel.Convert(img.At(x*2+1, 1)); got != want {
+			t.Errorf("cell %d = %v, want %v", x, got, want)
+		}
+	}
+}
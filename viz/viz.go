@@ -0,0 +1,183 @@
+// Package viz lets solvers emit frames which elver plays back in the terminal
+// with `elver viz`, e.g. to animate a grid while debugging:
+//
+//	for step := 0; step < n; step++ {
+//		...
+//		if viz.Enabled() {
+//			viz.EmitRunes(grid)
+//		}
+//	}
+//
+// Emitting frames is a no-op unless elver records them, so solvers can keep
+// emitting frames during normal runs and benchmarks.
+//
+// The frames are shared with elver through this package, so the year module
+// has to require the same version of github.com/aod/elver as the installed
+// elver. Otherwise the plugin of the year fails to load.
+package viz
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Color is one of the 16 terminal colors or the default color.
+type Color uint8
+
+const (
+	Default Color = iota
+	Black
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+	BrightBlack
+	BrightRed
+	BrightGreen
+	BrightYellow
+	BrightBlue
+	BrightMagenta
+	BrightCyan
+	BrightWhite
+)
+
+// Cell is a single character of a frame.
+type Cell struct {
+	R      rune
+	FG, BG Color
+}
+
+// Frame is a grid of cells with an optional caption shown below it.
+type Frame struct {
+	Cells   [][]Cell
+	Caption string
+}
+
+// NewFrame returns a frame of w by h blank cells.
+func NewFrame(w, h int) Frame {
+	f := Frame{Cells: make([][]Cell, h)}
+	for y := range f.Cells {
+		f.Cells[y] = make([]Cell, w)
+		for x := range f.Cells[y] {
+			f.Cells[y][x].R = ' '
+		}
+	}
+	return f
+}
+
+// Set sets the cell at x, y. Cells outside the frame are ignored.
+func (f Frame) Set(x, y int, r rune, fg Color) {
+	if y < 0 || y >= len(f.Cells) || x < 0 || x >= len(f.Cells[y]) {
+		return
+	}
+	f.Cells[y][x] = Cell{R: r, FG: fg}
+}
+
+// Size returns the width and height of the frame.
+func (f Frame) Size() (int, int) {
+	w := 0
+	for _, row := range f.Cells {
+		if len(row) > w {
+			w = len(row)
+		}
+	}
+	return w, len(f.Cells)
+}
+
+// Recorder receives the frames emitted by solvers.
+type Recorder interface {
+	Record(Frame)
+}
+
+// recorder holds a *recorderBox, a nil box disables recording.
+var recorder atomic.Value
+
+type recorderBox struct {
+	mu sync.Mutex
+	r  Recorder
+}
+
+// SetRecorder makes r receive all emitted frames, nil stops recording. It is
+// called by elver and not meant to be used by solvers.
+func SetRecorder(r Recorder) {
+	if r == nil {
+		recorder.Store((*recorderBox)(nil))
+		return
+	}
+	recorder.Store(&recorderBox{r: r})
+}
+
+func current() *recorderBox {
+	b, _ := recorder.Load().(*recorderBox)
+	return b
+}
+
+// Enabled reports whether frames are recorded. Use it to skip building frames
+// when they would be discarded.
+func Enabled() bool {
+	return current() != nil
+}
+
+// Emit emits a copy of f, so f may be reused for the next frame.
+func Emit(f Frame) {
+	b := current()
+	if b == nil {
+		return
+	}
+
+	cp := Frame{Cells: make([][]Cell, len(f.Cells)), Caption: f.Caption}
+	for y, row := range f.Cells {
+		cp.Cells[y] = append([]Cell(nil), row...)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.r.Record(cp)
+}
+
+// EmitRunes emits a frame of rows in the default color.
+func EmitRunes(rows [][]rune) {
+	if !Enabled() {
+		return
+	}
+	f := Frame{Cells: make([][]Cell, len(rows))}
+	for y, row := range rows {
+		f.Cells[y] = make([]Cell, len(row))
+		for x, r := range row {
+			f.Cells[y][x].R = r
+		}
+	}
+	Emit(f)
+}
+
+// EmitLines emits a frame of lines in the default color.
+func EmitLines(lines ...string) {
+	if !Enabled() {
+		return
+	}
+	rows := make([][]rune, len(lines))
+	for i, line := range lines {
+		rows[i] = []rune(line)
+	}
+	EmitRunes(rows)
+}
+
+// Recording is a Recorder which keeps up to Max frames, or all frames when
+// Max is 0.
+type Recording struct {
+	Frames []Frame
+	Max    int
+	// Dropped is the number of frames which did not fit.
+	Dropped int
+}
+
+// Record implements Recorder.
+func (r *Recording) Record(f Frame) {
+	if r.Max > 0 && len(r.Frames) >= r.Max {
+		r.Dropped++
+		return
+	}
+	r.Frames = append(r.Frames, f)
+}
@@ -0,0 +1,37 @@
+package viz
+
+import "testing"
+
+func TestEmit(t *testing.T) {
+	EmitLines("ignored")
+
+	rec := &Recording{Max: 2}
+	SetRecorder(rec)
+	defer SetRecorder(nil)
+	if !Enabled() {
+		t.Fatal("Enabled() = false while recording")
+	}
+
+	f := NewFrame(2, 1)
+	f.Set(1, 0, '#', Red)
+	f.Set(5, 5, '#', Red)
+	Emit(f)
+	f.Set(0, 0, '@', Blue)
+	EmitLines("ab")
+	EmitLines("cd")
+
+	if len(rec.Frames) != 2 || rec.Dropped != 1 {
+		t.Fatalf("recorded %d frames and dropped %d, want 2 and 1", len(rec.Frames), rec.Dropped)
+	}
+	if got := rec.Frames[0].Cells[0]; got[0].R != ' ' || got[1] != (Cell{R: '#', FG: Red}) {
+		t.Errorf("first frame = %v, want a copy of the emitted frame", got)
+	}
+	if got := string(rec.Frames[1].Cells[0][1].R); got != "b" {
+		t.Errorf("second frame ends with %q, want b", got)
+	}
+
+	SetRecorder(nil)
+	if Enabled() {
+		t.Error("Enabled() = true after stopping recording")
+	}
+}